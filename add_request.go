@@ -0,0 +1,317 @@
+package qbittorrent
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/Masterminds/semver"
+
+	"github.com/autobrr/go-qbittorrent/errors"
+	"github.com/autobrr/go-qbittorrent/pkg/metainfo"
+)
+
+// StopCondition stops a newly added torrent once it is reached (BitTorrent::Torrent::StopCondition).
+type StopCondition string
+
+const (
+	StopConditionNone             StopCondition = "None"
+	StopConditionMetadataReceived StopCondition = "MetadataReceived"
+	StopConditionFilesChecked     StopCondition = "FilesChecked"
+)
+
+// Special values for the ratio and seeding time limits of AddShareLimits
+const (
+	ShareLimitUseGlobal = -2
+	ShareLimitUnlimited = -1
+)
+
+// WebAPI versions that introduced torrents/add parameters
+var (
+	addContentLayoutVersion    = semver.MustParse("2.7.0")  // qBittorrent 4.3.2, replaces root_folder
+	addStopConditionVersion    = semver.MustParse("2.8.15") // qBittorrent 4.5.0
+	addInactiveSeedingVersion  = semver.MustParse("2.9.2")  // qBittorrent 4.6.0
+	addStoppedVersion          = semver.MustParse("2.11.0") // qBittorrent 5.0.0, replaces paused
+	addForcedVersion           = semver.MustParse("2.11.0") // qBittorrent 5.0.0
+	addShareLimitActionVersion = semver.MustParse("2.12.0")
+)
+
+// AddRequest adds any mix of .torrent files, in-memory .torrent payloads, URLs and magnet links
+// with a single set of options.
+type AddRequest struct {
+	// Files are paths of local .torrent files
+	Files []string
+	// Torrents are .torrent files held in memory
+	Torrents [][]byte
+	// URLs are links to .torrent files or magnet links
+	URLs    []string
+	Options AddOptions
+}
+
+// AddOptions are the typed options of torrents/add. The zero value leaves every setting to
+// qBittorrent's defaults. Parameters that changed between WebAPI versions are translated for
+// the connected server, and options the server does not support fail with ErrUnsupportedVersion.
+type AddOptions struct {
+	SavePath string
+	// DownloadPath sets the incomplete download path and enables it for the torrent
+	DownloadPath string
+	Category     string
+	Tags         []string
+	// Rename sets the torrent name; it requires a request with a single torrent
+	Rename string
+	// AutoTMM enables or disables automatic torrent management; nil uses the server default
+	AutoTMM *bool
+	// Stopped adds the torrent stopped; sent as paused to servers before WebAPI 2.11.0
+	Stopped bool
+	// Forced starts the torrent forced, ignoring queue limits. Requires WebAPI 2.11.0.
+	Forced bool
+	// StopCondition requires WebAPI 2.8.15
+	StopCondition StopCondition
+	SkipHashCheck bool
+	// ContentLayout is sent as root_folder to servers before WebAPI 2.7.0
+	ContentLayout      ContentLayout
+	SequentialDownload bool
+	FirstLastPiecePrio bool
+	// UploadLimit and DownloadLimit are in bytes per second; zero means unlimited
+	UploadLimit   int64
+	DownloadLimit int64
+	// ShareLimits overrides the share limits; nil uses the server defaults
+	ShareLimits *AddShareLimits
+}
+
+// AddShareLimits sets the share limits of added torrents. All limits are sent together, so
+// servers do not fall back to version-specific defaults for the ones left out; use
+// DefaultAddShareLimits to start from the global limits.
+type AddShareLimits struct {
+	// RatioLimit is the maximum ratio, or ShareLimitUseGlobal or ShareLimitUnlimited
+	RatioLimit float64
+	// SeedingTimeLimit is in minutes, or ShareLimitUseGlobal or ShareLimitUnlimited
+	SeedingTimeLimit int64
+	// InactiveSeedingTimeLimit is in minutes, or ShareLimitUseGlobal or ShareLimitUnlimited.
+	// Values other than ShareLimitUseGlobal require WebAPI 2.9.2.
+	InactiveSeedingTimeLimit int64
+	// ShareLimitAction is one of the ShareLimitAction constants. Requires WebAPI 2.12.0.
+	ShareLimitAction string
+}
+
+// DefaultAddShareLimits returns share limits that follow the global settings
+func DefaultAddShareLimits() AddShareLimits {
+	return AddShareLimits{
+		RatioLimit:               ShareLimitUseGlobal,
+		SeedingTimeLimit:         ShareLimitUseGlobal,
+		InactiveSeedingTimeLimit: ShareLimitUseGlobal,
+	}
+}
+
+// Validate checks the request for missing sources, invalid magnet links and conflicting options.
+// Errors wrap ErrInvalidAddRequest or metainfo.ErrInvalidMagnet.
+func (r *AddRequest) Validate() error {
+	sources := len(r.Files) + len(r.Torrents) + len(r.URLs)
+	if sources == 0 {
+		return errors.Wrap(ErrInvalidAddRequest, "no files, torrents or urls")
+	}
+
+	for _, url := range r.URLs {
+		if url == "" {
+			return errors.Wrap(ErrInvalidAddRequest, "empty url")
+		}
+		if metainfo.IsMagnetURI(url) {
+			if _, err := metainfo.ParseMagnet(url); err != nil {
+				return errors.Wrap(err, "url: %s", url)
+			}
+		}
+	}
+
+	o := r.Options
+	autoTMM := o.AutoTMM != nil && *o.AutoTMM
+
+	switch {
+	case o.Rename != "" && sources > 1:
+		return errors.Wrap(ErrInvalidAddRequest, "rename requires a single torrent, got %d", sources)
+	case autoTMM && o.SavePath != "":
+		return errors.Wrap(ErrInvalidAddRequest, "save path cannot be set with automatic torrent management")
+	case autoTMM && o.DownloadPath != "":
+		return errors.Wrap(ErrInvalidAddRequest, "download path cannot be set with automatic torrent management")
+	case o.Stopped && o.Forced:
+		return errors.Wrap(ErrInvalidAddRequest, "torrent cannot be both stopped and forced")
+	case o.Stopped && o.StopCondition != "" && o.StopCondition != StopConditionNone:
+		return errors.Wrap(ErrInvalidAddRequest, "stop condition has no effect on a stopped torrent")
+	case o.UploadLimit < 0 || o.DownloadLimit < 0:
+		return errors.Wrap(ErrInvalidAddRequest, "speed limits cannot be negative")
+	}
+
+	switch o.StopCondition {
+	case "", StopConditionNone, StopConditionMetadataReceived, StopConditionFilesChecked:
+	default:
+		return errors.Wrap(ErrInvalidAddRequest, "unknown stop condition %q", o.StopCondition)
+	}
+
+	switch o.ContentLayout {
+	case "", ContentLayoutOriginal, ContentLayoutSubfolderCreate, ContentLayoutSubfolderNone:
+	default:
+		return errors.Wrap(ErrInvalidAddRequest, "unknown content layout %q", o.ContentLayout)
+	}
+
+	if limits := o.ShareLimits; limits != nil {
+		if limits.RatioLimit < ShareLimitUseGlobal || limits.SeedingTimeLimit < ShareLimitUseGlobal || limits.InactiveSeedingTimeLimit < ShareLimitUseGlobal {
+			return errors.Wrap(ErrInvalidAddRequest, "share limits must be positive, ShareLimitUseGlobal or ShareLimitUnlimited")
+		}
+
+		switch limits.ShareLimitAction {
+		case "", ShareLimitActionDefault, ShareLimitActionStop, ShareLimitActionRemove,
+			ShareLimitActionEnableSuperSeeding, ShareLimitActionRemoveWithContent:
+		default:
+			return errors.Wrap(ErrInvalidAddRequest, "unknown share limit action %q", limits.ShareLimitAction)
+		}
+	}
+
+	return nil
+}
+
+// form translates the options into torrents/add parameters for the given WebAPI version
+func (o *AddOptions) form(version *semver.Version) (map[string]string, error) {
+	form := map[string]string{}
+
+	requires := func(minVersion *semver.Version, option string) error {
+		if version.LessThan(minVersion) {
+			return errors.Wrap(ErrUnsupportedVersion, "%s requires WebAPI %s, server has %s", option, minVersion, version)
+		}
+		return nil
+	}
+
+	if o.SavePath != "" {
+		form["savepath"] = o.SavePath
+	}
+	if o.DownloadPath != "" {
+		form["downloadPath"] = o.DownloadPath
+		form["useDownloadPath"] = "true"
+	}
+	if o.Category != "" {
+		form["category"] = o.Category
+	}
+	if len(o.Tags) > 0 {
+		form["tags"] = strings.Join(o.Tags, ",")
+	}
+	if o.Rename != "" {
+		form["rename"] = o.Rename
+	}
+	if o.AutoTMM != nil {
+		form["autoTMM"] = strconv.FormatBool(*o.AutoTMM)
+	}
+
+	switch {
+	case !o.Stopped:
+	case version.LessThan(addStoppedVersion):
+		form["paused"] = "true"
+	default:
+		form["stopped"] = "true"
+	}
+
+	if o.Forced {
+		if err := requires(addForcedVersion, "forced"); err != nil {
+			return nil, err
+		}
+		form["forced"] = "true"
+	}
+
+	if o.StopCondition != "" {
+		if err := requires(addStopConditionVersion, "stopCondition"); err != nil {
+			return nil, err
+		}
+		form["stopCondition"] = string(o.StopCondition)
+	}
+
+	if o.SkipHashCheck {
+		form["skip_checking"] = "true"
+	}
+
+	switch {
+	case o.ContentLayout == "":
+	case !version.LessThan(addContentLayoutVersion):
+		form["contentLayout"] = string(o.ContentLayout)
+	case o.ContentLayout == ContentLayoutSubfolderCreate:
+		form["root_folder"] = "true"
+	case o.ContentLayout == ContentLayoutSubfolderNone:
+		form["root_folder"] = "false"
+	}
+
+	if o.SequentialDownload {
+		form["sequentialDownload"] = "true"
+	}
+	if o.FirstLastPiecePrio {
+		form["firstLastPiecePrio"] = "true"
+	}
+	if o.UploadLimit > 0 {
+		form["upLimit"] = strconv.FormatInt(o.UploadLimit, 10)
+	}
+	if o.DownloadLimit > 0 {
+		form["dlLimit"] = strconv.FormatInt(o.DownloadLimit, 10)
+	}
+
+	if limits := o.ShareLimits; limits != nil {
+		form["ratioLimit"] = strconv.FormatFloat(limits.RatioLimit, 'f', 2, 64)
+		form["seedingTimeLimit"] = strconv.FormatInt(limits.SeedingTimeLimit, 10)
+
+		if limits.InactiveSeedingTimeLimit != ShareLimitUseGlobal {
+			if err := requires(addInactiveSeedingVersion, "inactiveSeedingTimeLimit"); err != nil {
+				return nil, err
+			}
+		}
+		if !version.LessThan(addInactiveSeedingVersion) {
+			form["inactiveSeedingTimeLimit"] = strconv.FormatInt(limits.InactiveSeedingTimeLimit, 10)
+		}
+
+		if limits.ShareLimitAction != "" && limits.ShareLimitAction != ShareLimitActionDefault {
+			if err := requires(addShareLimitActionVersion, "shareLimitAction"); err != nil {
+				return nil, err
+			}
+			form["shareLimitAction"] = limits.ShareLimitAction
+		}
+	}
+
+	return form, nil
+}
+
+// AddTorrents adds every torrent in req.
+func (c *Client) AddTorrents(req AddRequest) (*TorrentAddResponse, error) {
+	return c.AddTorrentsCtx(context.Background(), req)
+}
+
+// AddTorrentsCtx validates req, translates its options for the server's WebAPI version and adds
+// every file, in-memory torrent and URL. Files are streamed from disk, and batches larger than
+// Config.MaxRequestSize are split over several requests whose responses are merged. If one fails,
+// the responses so far are returned along with the error.
+func (c *Client) AddTorrentsCtx(ctx context.Context, req AddRequest) (*TorrentAddResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	version, err := c.getApiVersion()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get api version")
+	}
+
+	form, err := req.Options.form(version)
+	if err != nil {
+		return nil, err
+	}
+
+	uploads := make([]uploadFile, 0, len(req.Torrents)+len(req.Files))
+	for _, buf := range req.Torrents {
+		uploads = append(uploads, memoryUploadFile(buf))
+	}
+	for _, path := range req.Files {
+		upload, err := diskUploadFile(path)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not read torrent file %s", path)
+		}
+		uploads = append(uploads, upload)
+	}
+
+	var urls map[string]string
+	if len(req.URLs) > 0 {
+		urls = map[string]string{"urls": strings.Join(req.URLs, "\n")}
+	}
+
+	return c.uploadTorrentsCtx(ctx, uploads, form, urls)
+}
@@ -0,0 +1,149 @@
+package qbittorrent
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/autobrr/go-qbittorrent/pkg/metainfo"
+)
+
+// newAddTestServer serves version and records the multipart form of every torrents/add call
+func newAddTestServer(t *testing.T, version string, forms *[]*http.Request) *Client {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v2/app/webapiVersion", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(version))
+	})
+	mux.HandleFunc("/api/v2/torrents/add", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseMultipartForm(1<<20))
+		*forms = append(*forms, r)
+		w.Header().Set("Content-Type", "text/plain; charset=UTF-8")
+		_, _ = w.Write([]byte("Ok."))
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	return NewClient(Config{Host: server.URL})
+}
+
+func TestClient_AddTorrents(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "a.torrent")
+	require.NoError(t, os.WriteFile(path, []byte(infohashTestTorrent), 0o644))
+
+	var forms []*http.Request
+	client := newAddTestServer(t, "2.11.3", &forms)
+
+	res, err := client.AddTorrents(AddRequest{
+		Files:    []string{path},
+		Torrents: [][]byte{[]byte("d4:infode")},
+		URLs:     []string{"https://example.com/b.torrent", "magnet:?xt=urn:btih:" + infohashTestHash},
+		Options: AddOptions{
+			SavePath:      "/data",
+			Tags:          []string{"a", "b"},
+			Stopped:       true,
+			ContentLayout: ContentLayoutSubfolderNone,
+			UploadLimit:   1024,
+			ShareLimits:   &AddShareLimits{RatioLimit: 2, SeedingTimeLimit: ShareLimitUnlimited, InactiveSeedingTimeLimit: 60},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"", infohashTestHash}, res.ExpectedTorrentIds, "one id per file, in upload order")
+
+	require.Len(t, forms, 1)
+	form := forms[0].MultipartForm
+	assert.Len(t, form.File["torrents"], 2)
+	assert.Equal(t, map[string][]string{
+		"savepath":                 {"/data"},
+		"tags":                     {"a,b"},
+		"stopped":                  {"true"},
+		"contentLayout":            {"NoSubfolder"},
+		"upLimit":                  {"1024"},
+		"ratioLimit":               {"2.00"},
+		"seedingTimeLimit":         {"-1"},
+		"inactiveSeedingTimeLimit": {"60"},
+		"urls":                     {"https://example.com/b.torrent\nmagnet:?xt=urn:btih:" + infohashTestHash},
+	}, form.Value)
+}
+
+func TestClient_AddTorrents_LegacyServer(t *testing.T) {
+	var forms []*http.Request
+	client := newAddTestServer(t, "2.6.2", &forms)
+
+	_, err := client.AddTorrents(AddRequest{
+		URLs:    []string{"https://example.com/a.torrent"},
+		Options: AddOptions{Stopped: true, ContentLayout: ContentLayoutSubfolderCreate, ShareLimits: &AddShareLimits{RatioLimit: 1, SeedingTimeLimit: ShareLimitUseGlobal, InactiveSeedingTimeLimit: ShareLimitUseGlobal}},
+	})
+	require.NoError(t, err)
+
+	require.Len(t, forms, 1)
+	values := forms[0].MultipartForm.Value
+	assert.Equal(t, []string{"true"}, values["paused"])
+	assert.Equal(t, []string{"true"}, values["root_folder"])
+	assert.NotContains(t, values, "stopped")
+	assert.NotContains(t, values, "contentLayout")
+	assert.NotContains(t, values, "inactiveSeedingTimeLimit")
+
+	for name, options := range map[string]AddOptions{
+		"stop condition": {StopCondition: StopConditionMetadataReceived},
+		"forced":         {Forced: true},
+		"inactive limit": {ShareLimits: &AddShareLimits{InactiveSeedingTimeLimit: 10}},
+		"limit action":   {ShareLimits: &AddShareLimits{ShareLimitAction: ShareLimitActionRemove}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := client.AddTorrents(AddRequest{URLs: []string{"https://example.com/a.torrent"}, Options: options})
+			assert.ErrorIs(t, err, ErrUnsupportedVersion)
+		})
+	}
+	assert.Len(t, forms, 1, "unsupported options must not reach the server")
+}
+
+func TestClient_AddTorrents_ZeroOptions(t *testing.T) {
+	for _, version := range []string{"2.6.2", "2.11.3"} {
+		t.Run(version, func(t *testing.T) {
+			var forms []*http.Request
+			client := newAddTestServer(t, version, &forms)
+
+			_, err := client.AddTorrents(AddRequest{URLs: []string{"https://example.com/a.torrent"}})
+			require.NoError(t, err)
+
+			require.Len(t, forms, 1)
+			values := forms[0].MultipartForm.Value
+			assert.NotContains(t, values, "paused", "the server's add stopped preference applies")
+			assert.NotContains(t, values, "stopped", "the server's add stopped preference applies")
+		})
+	}
+}
+
+func TestAddRequest_Validate(t *testing.T) {
+	enabled := true
+	url := []string{"https://example.com/a.torrent"}
+
+	for name, req := range map[string]AddRequest{
+		"no sources":          {},
+		"empty url":           {URLs: []string{""}},
+		"rename many":         {URLs: []string{"a", "b"}, Options: AddOptions{Rename: "x"}},
+		"auto tmm save path":  {URLs: url, Options: AddOptions{AutoTMM: &enabled, SavePath: "/data"}},
+		"stopped and forced":  {URLs: url, Options: AddOptions{Stopped: true, Forced: true}},
+		"stopped with cond":   {URLs: url, Options: AddOptions{Stopped: true, StopCondition: StopConditionFilesChecked}},
+		"unknown layout":      {URLs: url, Options: AddOptions{ContentLayout: "Flat"}},
+		"invalid share limit": {URLs: url, Options: AddOptions{ShareLimits: &AddShareLimits{RatioLimit: -3}}},
+		"unknown action":      {URLs: url, Options: AddOptions{ShareLimits: &AddShareLimits{ShareLimitAction: "Explode"}}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.ErrorIs(t, req.Validate(), ErrInvalidAddRequest)
+		})
+	}
+
+	err := (&AddRequest{URLs: []string{"magnet:?dn=nohash"}}).Validate()
+	assert.ErrorIs(t, err, metainfo.ErrInvalidMagnet)
+
+	assert.NoError(t, (&AddRequest{URLs: url, Options: AddOptions{ShareLimits: &AddShareLimits{}}}).Validate())
+}
@@ -0,0 +1,177 @@
+package qbittorrent
+
+import (
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/autobrr/go-qbittorrent/errors"
+)
+
+const (
+	// AggregateByTag groups torrents by each of their tags; a torrent with several tags is
+	// counted in every one of those groups and a torrent without tags in the "" group.
+	AggregateByTag = "tag"
+	// AggregateByTrackerHost groups torrents by the lowercased hostname of their current tracker.
+	AggregateByTrackerHost = "tracker_host"
+)
+
+// TorrentAggregation describes how AggregateTorrents groups and summarises torrents
+type TorrentAggregation struct {
+	// GroupBy lists the grouping keys: AggregateByTag, AggregateByTrackerHost, or the JSON name of
+	// any string or bool Torrent field such as "category" or "state". No keys yields a single group.
+	GroupBy []string
+	// Fields lists the JSON names of the numeric Torrent fields to summarise, e.g. "size" or "ratio".
+	Fields []string
+}
+
+// TorrentStats summarises one numeric field over a group of torrents
+type TorrentStats struct {
+	Sum     float64 `json:"sum"`
+	Min     float64 `json:"min"`
+	Max     float64 `json:"max"`
+	Average float64 `json:"average"`
+}
+
+// TorrentGroup is one group produced by AggregateTorrents
+type TorrentGroup struct {
+	// Key holds the group's value for each TorrentAggregation.GroupBy entry, in the same order
+	Key   []string                `json:"key"`
+	Count int                     `json:"count"`
+	Stats map[string]TorrentStats `json:"stats"`
+}
+
+// groupKeyFunc returns the values of one grouping key for a torrent
+type groupKeyFunc func(t *Torrent) []string
+
+// AggregateTorrents groups torrents by agg.GroupBy and computes count, sum, min, max and
+// average of agg.Fields for every group. Groups are ordered by key. Errors wrap ErrInvalidAggregation.
+func AggregateTorrents(torrents []Torrent, agg TorrentAggregation) ([]TorrentGroup, error) {
+	keyFuncs := make([]groupKeyFunc, 0, len(agg.GroupBy))
+	for _, name := range agg.GroupBy {
+		keyFunc, err := aggregationGroupKey(name)
+		if err != nil {
+			return nil, err
+		}
+		keyFuncs = append(keyFuncs, keyFunc)
+	}
+
+	fields := make([]func(t *Torrent) float64, 0, len(agg.Fields))
+	for _, name := range agg.Fields {
+		field, ok := torrentQueryFields[name]
+		if !ok || field.kind != queryKindNumber {
+			return nil, errors.Wrap(ErrInvalidAggregation, "field %q is not a numeric torrent field", name)
+		}
+		fields = append(fields, field.number)
+	}
+
+	groups := make(map[string]*TorrentGroup)
+	for i := range torrents {
+		t := &torrents[i]
+		for _, key := range groupKeys(t, keyFuncs) {
+			id := strings.Join(key, "\x00")
+			group, ok := groups[id]
+			if !ok {
+				group = &TorrentGroup{Key: key, Stats: make(map[string]TorrentStats, len(fields))}
+				groups[id] = group
+			}
+			group.Count++
+
+			for j, field := range fields {
+				value := field(t)
+				stats, seen := group.Stats[agg.Fields[j]]
+				if !seen {
+					stats.Min, stats.Max = value, value
+				}
+				stats.Sum += value
+				stats.Min = math.Min(stats.Min, value)
+				stats.Max = math.Max(stats.Max, value)
+				group.Stats[agg.Fields[j]] = stats
+			}
+		}
+	}
+
+	result := make([]TorrentGroup, 0, len(groups))
+	for _, group := range groups {
+		for name, stats := range group.Stats {
+			stats.Average = stats.Sum / float64(group.Count)
+			group.Stats[name] = stats
+		}
+		result = append(result, *group)
+	}
+
+	slices.SortFunc(result, func(a, b TorrentGroup) int {
+		return slices.Compare(a.Key, b.Key)
+	})
+
+	return result, nil
+}
+
+func aggregationGroupKey(name string) (groupKeyFunc, error) {
+	switch name {
+	case AggregateByTag:
+		return func(t *Torrent) []string {
+			tags := indexKeysFor(*t).tags
+			if len(tags) == 0 {
+				return []string{""}
+			}
+			return tags
+		}, nil
+	case AggregateByTrackerHost:
+		return func(t *Torrent) []string { return []string{trackerHostname(t.Tracker)} }, nil
+	}
+
+	field, ok := torrentQueryFields[name]
+	if !ok {
+		return nil, errors.Wrap(ErrInvalidAggregation, "unknown group key %q", name)
+	}
+
+	switch field.kind {
+	case queryKindString:
+		return func(t *Torrent) []string { return []string{field.str(t)} }, nil
+	case queryKindBool:
+		return func(t *Torrent) []string { return []string{strconv.FormatBool(field.boolean(t))} }, nil
+	default:
+		return nil, errors.Wrap(ErrInvalidAggregation, "cannot group by numeric field %q", name)
+	}
+}
+
+// groupKeys returns every group key tuple t belongs to; multi-valued keys such as tags expand
+// into one tuple per value.
+func groupKeys(t *Torrent, keyFuncs []groupKeyFunc) [][]string {
+	keys := [][]string{make([]string, 0, len(keyFuncs))}
+
+	for _, keyFunc := range keyFuncs {
+		values := keyFunc(t)
+		expanded := make([][]string, 0, len(keys)*len(values))
+		for _, key := range keys {
+			for _, value := range values {
+				expanded = append(expanded, append(slices.Clip(key), value))
+			}
+		}
+		keys = expanded
+	}
+
+	return keys
+}
+
+// AggregateTorrents groups the torrents matching options and summarises agg.Fields for each group.
+// Sort, offset and limit in options are ignored.
+func (sm *SyncManager) AggregateTorrents(options TorrentFilterOptions, agg TorrentAggregation) ([]TorrentGroup, error) {
+	sm.ensureFreshData()
+	return sm.AggregateTorrentsUnchecked(options, agg)
+}
+
+// AggregateTorrentsUnchecked is AggregateTorrents without checking freshness.
+func (sm *SyncManager) AggregateTorrentsUnchecked(options TorrentFilterOptions, agg TorrentAggregation) ([]TorrentGroup, error) {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	var torrents []Torrent
+	if sm.data != nil {
+		torrents = sm.collectMatchingTorrents(nil, options)
+	}
+
+	return AggregateTorrents(torrents, agg)
+}
@@ -0,0 +1,84 @@
+package qbittorrent
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/autobrr/go-qbittorrent/errors"
+)
+
+func TestAggregateTorrents(t *testing.T) {
+	torrents := queryTestTorrents()
+
+	groups, err := AggregateTorrents(torrents, TorrentAggregation{GroupBy: []string{"category"}, Fields: []string{"ratio"}})
+	require.NoError(t, err)
+	require.Len(t, groups, 3)
+
+	assert.Equal(t, []string{""}, groups[0].Key)
+	assert.Equal(t, []string{"movies"}, groups[1].Key)
+	assert.Equal(t, []string{"tv"}, groups[2].Key)
+	assert.Equal(t, 2, groups[2].Count)
+	assert.Equal(t, TorrentStats{Sum: 5.5, Min: 2.5, Max: 3, Average: 2.75}, groups[2].Stats["ratio"])
+
+	t.Run("tags expand into every group", func(t *testing.T) {
+		groups, err := AggregateTorrents(torrents, TorrentAggregation{GroupBy: []string{AggregateByTag, "private"}, Fields: []string{"size"}})
+		require.NoError(t, err)
+
+		var keys [][]string
+		for _, group := range groups {
+			keys = append(keys, group.Key)
+		}
+		assert.Equal(t, [][]string{{"", "false"}, {"hd", "false"}, {"hd", "true"}, {"keep", "true"}, {"sd", "false"}}, keys)
+		assert.Equal(t, float64(8<<30), groups[1].Stats["size"].Sum)
+	})
+
+	t.Run("tracker host", func(t *testing.T) {
+		groups, err := AggregateTorrents(torrents, TorrentAggregation{GroupBy: []string{AggregateByTrackerHost}})
+		require.NoError(t, err)
+		require.Len(t, groups, 4)
+		assert.Equal(t, []string{"tracker.example.org"}, groups[3].Key)
+	})
+
+	t.Run("no group keys", func(t *testing.T) {
+		groups, err := AggregateTorrents(torrents, TorrentAggregation{Fields: []string{"upspeed"}})
+		require.NoError(t, err)
+		require.Len(t, groups, 1)
+		assert.Equal(t, 4, groups[0].Count)
+		assert.Empty(t, groups[0].Key)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		for _, agg := range []TorrentAggregation{
+			{GroupBy: []string{"nope"}},
+			{GroupBy: []string{"size"}},
+			{Fields: []string{"name"}},
+			{Fields: []string{"missing"}},
+		} {
+			_, err := AggregateTorrents(torrents, agg)
+			assert.True(t, errors.Is(err, ErrInvalidAggregation), "%+v: got %v", agg, err)
+		}
+	})
+}
+
+func TestSyncManager_AggregateTorrents(t *testing.T) {
+	torrents := map[string]Torrent{}
+	for _, torrent := range queryTestTorrents() {
+		torrents[torrent.Hash] = torrent
+	}
+
+	body, err := json.Marshal(map[string]interface{}{"rid": 1, "full_update": true, "torrents": torrents})
+	require.NoError(t, err)
+
+	sm := newSyncManagerWithBodies(string(body))
+	require.NoError(t, sm.Sync(context.Background()))
+
+	groups, err := sm.AggregateTorrentsUnchecked(TorrentFilterOptions{Category: "tv", Limit: 1}, TorrentAggregation{GroupBy: []string{"state"}, Fields: []string{"size"}})
+	require.NoError(t, err)
+	require.Len(t, groups, 2)
+	assert.Equal(t, []string{string(TorrentStateDownloading)}, groups[0].Key)
+	assert.Equal(t, float64(8<<30), groups[0].Stats["size"].Max)
+}
@@ -19,9 +19,19 @@ func TestMaindataUpdatersGeneratedIsUpToDate(t *testing.T) {
 	assertGeneratedFileUpToDate(t, "internal/codegen/generate_maindata_updaters.go", "maindata_updaters_generated.go")
 }
 
+func TestPreferencesPatchGeneratedIsUpToDate(t *testing.T) {
+	assertGeneratedFileUpToDate(t, "internal/codegen/generate_preferences_patch.go", "preferences_patch_generated.go")
+}
+
+func TestQueryFieldsGeneratedIsUpToDate(t *testing.T) {
+	assertGeneratedFileUpToDate(t, "internal/codegen/generate_torrent_query.go", "query_fields_generated.go")
+}
+
 func TestAllGeneratedFilesAreUpToDate(t *testing.T) {
 	t.Run("FilterGenerated", TestFilterGeneratedIsUpToDate)
 	t.Run("MaindataUpdatersGenerated", TestMaindataUpdatersGeneratedIsUpToDate)
+	t.Run("PreferencesPatchGenerated", TestPreferencesPatchGeneratedIsUpToDate)
+	t.Run("QueryFieldsGenerated", TestQueryFieldsGeneratedIsUpToDate)
 }
 
 func assertGeneratedFileUpToDate(t *testing.T, generatorPath, generatedFile string) {
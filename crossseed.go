@@ -0,0 +1,214 @@
+package qbittorrent
+
+import (
+	"cmp"
+	"context"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/autobrr/go-qbittorrent/errors"
+	"github.com/autobrr/go-qbittorrent/pkg/metainfo"
+)
+
+// ExistingTorrentMatchKind describes how a candidate matched a torrent already in the client
+type ExistingTorrentMatchKind int
+
+const (
+	// MatchInfoHash means the client already has this exact torrent
+	MatchInfoHash ExistingTorrentMatchKind = iota + 1
+	// MatchFileLayout means a different, completed torrent has the same file names and sizes,
+	// so the candidate can be cross-seeded from its data
+	MatchFileLayout
+)
+
+func (k ExistingTorrentMatchKind) String() string {
+	switch k {
+	case MatchInfoHash:
+		return "infohash"
+	case MatchFileLayout:
+		return "file layout"
+	default:
+		return "none"
+	}
+}
+
+// ExistingTorrentMatch is a torrent in the client that matches a candidate
+type ExistingTorrentMatch struct {
+	Kind     ExistingTorrentMatchKind
+	Torrent  Torrent
+	SavePath string
+	// AddOptions adds the candidate on top of the matched torrent's data without rechecking it.
+	// It is only set for MatchFileLayout.
+	AddOptions *TorrentAddOptions
+}
+
+// layoutFile is a file path, relative to the torrent's root folder, and its size
+type layoutFile struct {
+	path string
+	size int64
+}
+
+// FindExistingTorrent reports whether the client already has the .torrent in buf.
+func (c *Client) FindExistingTorrent(buf []byte) (*ExistingTorrentMatch, error) {
+	return c.FindExistingTorrentCtx(context.Background(), buf)
+}
+
+// FindExistingTorrentCtx reports whether the client already has the .torrent in buf, first by
+// infohash and then by looking for a completed torrent with the same file names and sizes.
+// It returns nil if there is no match.
+func (c *Client) FindExistingTorrentCtx(ctx context.Context, buf []byte) (*ExistingTorrentMatch, error) {
+	meta, err := metainfo.Parse(buf)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not parse torrent")
+	}
+
+	match, err := c.findByInfoHashes(ctx, meta.InfoHashes())
+	if err != nil || match != nil {
+		return match, err
+	}
+
+	return c.findByFileLayout(ctx, meta)
+}
+
+// FindExistingMagnet reports whether the client already has the torrent of a magnet link.
+func (c *Client) FindExistingMagnet(magnet string) (*ExistingTorrentMatch, error) {
+	return c.FindExistingMagnetCtx(context.Background(), magnet)
+}
+
+// FindExistingMagnetCtx reports whether the client already has the torrent of a magnet link.
+// Magnets carry no file list, so only infohashes are compared. It returns nil if there is no match.
+func (c *Client) FindExistingMagnetCtx(ctx context.Context, magnet string) (*ExistingTorrentMatch, error) {
+	m, err := metainfo.ParseMagnet(magnet)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not parse magnet")
+	}
+
+	return c.findByInfoHashes(ctx, m.InfoHashes)
+}
+
+func (c *Client) findByInfoHashes(ctx context.Context, hashes metainfo.InfoHashes) (*ExistingTorrentMatch, error) {
+	// clients built against libtorrent 1.x identify hybrid torrents by their v1 hash
+	ids := removeDuplicateStrings([]string{hashes.ID(), hashes.V1})
+	ids = slices.DeleteFunc(ids, func(id string) bool { return id == "" })
+
+	torrents, err := c.GetTorrentsCtx(ctx, TorrentFilterOptions{Hashes: ids})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not look up torrent by infohash")
+	}
+
+	for _, torrent := range torrents {
+		if torrent.MatchesInfoHashes(hashes) {
+			return &ExistingTorrentMatch{Kind: MatchInfoHash, Torrent: torrent, SavePath: torrent.SavePath}, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (c *Client) findByFileLayout(ctx context.Context, meta *metainfo.MetaInfo) (*ExistingTorrentMatch, error) {
+	var paths [][]string
+	var sizes []int64
+	var totalWithPadding int64
+	for _, file := range meta.Files {
+		totalWithPadding += file.Length
+		if file.Padding {
+			continue
+		}
+
+		// single-file paths are just the name, so the extra root folder is stripped again below
+		paths = append(paths, append([]string{meta.Name}, file.Path...))
+		sizes = append(sizes, file.Length)
+	}
+	want, _ := normalizeLayout(paths, sizes)
+	total := meta.TotalLength()
+
+	torrents, err := c.GetTorrentsCtx(ctx, TorrentFilterOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get torrents")
+	}
+
+	for _, torrent := range torrents {
+		if torrent.Progress < 1 || (torrent.TotalSize != total && torrent.TotalSize != totalWithPadding) {
+			continue
+		}
+
+		files, err := c.GetFilesInformationCtx(ctx, torrent.Hash)
+		if err != nil {
+			if errors.Is(err, ErrTorrentNotFound) {
+				continue
+			}
+			return nil, err
+		}
+
+		have, root := torrentFilesLayout(*files)
+		if !slices.Equal(want, have) {
+			continue
+		}
+
+		// the candidate's files are placed directly in the folder that holds the existing files.
+		// ContentPath is not used, as it is the file itself for a torrent with a single file.
+		savePath := torrent.SavePath
+		if root != "" {
+			savePath = path.Join(torrent.SavePath, root)
+		}
+
+		return &ExistingTorrentMatch{
+			Kind:     MatchFileLayout,
+			Torrent:  torrent,
+			SavePath: savePath,
+			AddOptions: &TorrentAddOptions{
+				SavePath:      savePath,
+				ContentLayout: ContentLayoutSubfolderNone,
+				SkipHashCheck: true,
+			},
+		}, nil
+	}
+
+	return nil, nil
+}
+
+// torrentFilesLayout returns the layout of the files reported by qBittorrent, skipping padding files
+func torrentFilesLayout(files TorrentFiles) ([]layoutFile, string) {
+	var paths [][]string
+	var sizes []int64
+	for _, file := range files {
+		path := strings.Split(strings.ReplaceAll(file.Name, "\\", "/"), "/")
+		if slices.Contains(path[:len(path)-1], ".pad") {
+			continue
+		}
+		paths = append(paths, path)
+		sizes = append(sizes, file.Size)
+	}
+
+	return normalizeLayout(paths, sizes)
+}
+
+// normalizeLayout strips the root folder shared by every path, which content layout and renames
+// may change, and returns the files sorted by path along with the stripped root folder.
+func normalizeLayout(paths [][]string, sizes []int64) ([]layoutFile, string) {
+	root := ""
+	if len(paths) > 0 && len(paths[0]) > 1 {
+		root = paths[0][0]
+		for _, path := range paths {
+			if len(path) < 2 || path[0] != root {
+				root = ""
+				break
+			}
+		}
+	}
+
+	layout := make([]layoutFile, 0, len(paths))
+	for i, path := range paths {
+		if root != "" {
+			path = path[1:]
+		}
+		layout = append(layout, layoutFile{path: strings.Join(path, "/"), size: sizes[i]})
+	}
+
+	slices.SortFunc(layout, func(a, b layoutFile) int {
+		return cmp.Or(strings.Compare(a.path, b.path), cmp.Compare(a.size, b.size))
+	})
+
+	return layout, root
+}
@@ -0,0 +1,147 @@
+package qbittorrent
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/autobrr/go-qbittorrent/pkg/metainfo"
+)
+
+func newCrossSeedTestServer(t *testing.T, torrents []Torrent, files map[string]TorrentFiles) *httptest.Server {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v2/torrents/info", func(w http.ResponseWriter, r *http.Request) {
+		result := torrents
+		if hashes := r.FormValue("hashes"); hashes != "" {
+			result = nil
+			for _, torrent := range torrents {
+				if strings.Contains(hashes, torrent.Hash) {
+					result = append(result, torrent)
+				}
+			}
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(result))
+	})
+	mux.HandleFunc("/api/v2/torrents/files", func(w http.ResponseWriter, r *http.Request) {
+		list, ok := files[r.FormValue("hash")]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(list))
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func crossSeedTestTorrent(t *testing.T, name string) []byte {
+	t.Helper()
+
+	return crossSeedTestTorrentWithFiles(t, name,
+		map[string]any{"length": 100, "path": []any{"a.mkv"}},
+		map[string]any{"length": 20, "path": []any{"Subs", "a.srt"}},
+	)
+}
+
+func crossSeedTestTorrentWithFiles(t *testing.T, name string, files ...any) []byte {
+	t.Helper()
+
+	data, err := metainfo.Encode(map[string]any{
+		"info": map[string]any{
+			"name":         name,
+			"piece length": 16384,
+			"pieces":       strings.Repeat("x", 20),
+			"source":       "OTHER",
+			"files":        files,
+		},
+	})
+	require.NoError(t, err)
+
+	return data
+}
+
+func TestClient_FindExistingTorrent_InfoHash(t *testing.T) {
+	server := newCrossSeedTestServer(t, []Torrent{
+		{Hash: infohashTestHash, InfohashV1: infohashTestHash, SavePath: "/data"},
+	}, nil)
+	client := NewClient(Config{Host: server.URL})
+
+	match, err := client.FindExistingTorrent([]byte(infohashTestTorrent))
+	require.NoError(t, err)
+	require.NotNil(t, match)
+	assert.Equal(t, MatchInfoHash, match.Kind)
+	assert.Equal(t, "/data", match.SavePath)
+	assert.Nil(t, match.AddOptions)
+
+	match, err = client.FindExistingMagnet("magnet:?xt=urn:btih:" + infohashTestHash)
+	require.NoError(t, err)
+	require.NotNil(t, match)
+	assert.Equal(t, infohashTestHash, match.Torrent.Hash)
+}
+
+func TestClient_FindExistingTorrent_FileLayout(t *testing.T) {
+	server := newCrossSeedTestServer(t, []Torrent{
+		{Hash: "incomplete", Progress: 0.5, TotalSize: 120},
+		{Hash: "different", Progress: 1, TotalSize: 120},
+		{Hash: "same", Progress: 1, TotalSize: 120, SavePath: "/data", ContentPath: "/data/Show.Renamed"},
+	}, map[string]TorrentFiles{
+		"different": {{Name: "Show/a.mkv", Size: 110}, {Name: "Show/Subs/a.srt", Size: 10}},
+		"same":      {{Name: "Show.Renamed/Subs/a.srt", Size: 20}, {Name: "Show.Renamed/a.mkv", Size: 100}},
+	})
+	client := NewClient(Config{Host: server.URL})
+
+	match, err := client.FindExistingTorrent(crossSeedTestTorrent(t, "Show"))
+	require.NoError(t, err)
+	require.NotNil(t, match)
+
+	assert.Equal(t, MatchFileLayout, match.Kind)
+	assert.Equal(t, "same", match.Torrent.Hash)
+	assert.Equal(t, "/data/Show.Renamed", match.SavePath)
+	assert.Equal(t, &TorrentAddOptions{
+		SavePath:      "/data/Show.Renamed",
+		ContentLayout: ContentLayoutSubfolderNone,
+		SkipHashCheck: true,
+	}, match.AddOptions)
+
+	t.Run("single file in folder", func(t *testing.T) {
+		// qBittorrent reports the file itself as the content path of a torrent with one file
+		server := newCrossSeedTestServer(t, []Torrent{
+			{Hash: "same", Progress: 1, TotalSize: 100, SavePath: "/data", ContentPath: "/data/Movie/movie.mkv"},
+		}, map[string]TorrentFiles{
+			"same": {{Name: "Movie/movie.mkv", Size: 100}},
+		})
+		client := NewClient(Config{Host: server.URL})
+
+		match, err := client.FindExistingTorrent(crossSeedTestTorrentWithFiles(t, "Movie",
+			map[string]any{"length": 100, "path": []any{"movie.mkv"}},
+		))
+		require.NoError(t, err)
+		require.NotNil(t, match)
+
+		assert.Equal(t, "same", match.Torrent.Hash)
+		assert.Equal(t, "/data/Movie", match.SavePath)
+		assert.Equal(t, "/data/Movie", match.AddOptions.SavePath)
+	})
+}
+
+func TestClient_FindExistingTorrent_NoMatch(t *testing.T) {
+	server := newCrossSeedTestServer(t, []Torrent{{Hash: "other", Progress: 1, TotalSize: 999}}, nil)
+	client := NewClient(Config{Host: server.URL})
+
+	match, err := client.FindExistingTorrent(crossSeedTestTorrent(t, "Show"))
+	require.NoError(t, err)
+	assert.Nil(t, match)
+
+	_, err = client.FindExistingTorrent([]byte("garbage"))
+	assert.ErrorIs(t, err, metainfo.ErrInvalidBencode)
+}
@@ -19,6 +19,11 @@ var (
 	ErrEmptySavePath                   = errors.New("save path is empty")
 	ErrNoWriteAccessToPath             = errors.New("user does not have write access to directory")
 	ErrCannotCreateSavePath            = errors.New("unable to create save path directory")
+	ErrInvalidTorrentPath              = errors.New("path is empty or not absolute")
+	ErrTorrentPathForbidden            = errors.New("user does not have write access to path")
+	ErrTorrentPathConflict             = errors.New("unable to create directory at path")
+	ErrInvalidDirectoryPath            = errors.New("directory path is empty or not absolute")
+	ErrDirectoryNotFound               = errors.New("directory does not exist")
 	ErrEmptyCategoryName               = errors.New("category name is empty")
 	ErrInvalidCategoryName             = errors.New("category name is invalid")
 	ErrCategoryEditingFailed           = errors.New("category editing failed")
@@ -38,6 +43,7 @@ var (
 	ErrCannotGetTorrentPieceStates     = errors.New("could not get torrent piece states")
 	ErrInvalidPeers                    = errors.New("none of the supplied peers are valid")
 	ErrInvalidMonitoredFolderTarget    = errors.New("invalid monitored folder target")
+	ErrWebSeedNotFound                 = errors.New("web seed url was not found")
 
 	ErrReannounceTookTooLong = errors.New("reannounce took too long, deleted torrent")
 	ErrUnsupportedVersion    = errors.New("qBittorrent version too old, please upgrade to use this feature")
@@ -47,11 +53,28 @@ var (
 	ErrTorrentCreationUnfinished         = errors.New("torrent creation is still unfinished")
 	ErrTorrentCreationFailed             = errors.New("torrent creation failed")
 
-	ErrTorrentAddFailed = errors.New("torrent(s) failed to be added")
+	ErrTorrentAddFailed  = errors.New("torrent(s) failed to be added")
+	ErrInvalidAddRequest = errors.New("invalid add torrent request")
 
 	ErrRSSItemNotFound = errors.New("RSS item not found")
 	ErrRSSPathConflict = errors.New("RSS path already exists or is invalid")
 	ErrRSSRuleNotFound = errors.New("RSS rule not found")
+
+	ErrEmptySearchPattern  = errors.New("search pattern is empty")
+	ErrSearchJobNotFound   = errors.New("search job not found")
+	ErrSearchTooManyJobs   = errors.New("too many concurrent search jobs")
+	ErrSearchInvalidOffset = errors.New("search result offset is out of range")
+
+	ErrUnsupportedPreferencesSnapshot = errors.New("unsupported preferences snapshot format version")
+
+	ErrInvalidQuery       = errors.New("invalid torrent query")
+	ErrInvalidSortSpec    = errors.New("invalid torrent sort specification")
+	ErrInvalidAggregation = errors.New("invalid torrent aggregation")
+	ErrInvalidCursor      = errors.New("invalid pagination cursor")
+
+	ErrInvalidSyncSnapshot        = errors.New("invalid sync snapshot")
+	ErrSyncSnapshotChecksum       = errors.New("sync snapshot checksum mismatch")
+	ErrSyncSnapshotSchemaMismatch = errors.New("sync snapshot was written with a different schema")
 )
 
 type Torrent struct {
@@ -446,6 +469,10 @@ type TorrentAddResponse struct {
 	PendingCount    int64    `json:"pending_count"`
 	FailureCount    int64    `json:"failure_count"`
 	AddedTorrentIds []string `json:"added_torrent_ids"`
+	// ExpectedTorrentIds holds the hashes qBittorrent will report for the added .torrent files,
+	// computed locally so they are available on server versions without AddedTorrentIds.
+	// They line up with the added files; files that could not be parsed locally hold "".
+	ExpectedTorrentIds []string `json:"-"`
 }
 
 func ParseTorrentFilter(filter string) TorrentFilter {
@@ -493,6 +520,9 @@ type TorrentFilterOptions struct {
 	Offset          int
 	Hashes          []string
 	IncludeTrackers bool // qbit 5.1+
+	// SortKeys sorts by several fields with per-key direction and takes precedence over Sort and Reverse.
+	// It is applied locally by SyncManager and QueryTorrents; the WebAPI only supports Sort.
+	SortKeys []TorrentSortKey
 }
 
 type TorrentProperties struct {
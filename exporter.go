@@ -0,0 +1,214 @@
+package qbittorrent
+
+import (
+	"cmp"
+	"net/http"
+	"slices"
+	"sync"
+
+	"github.com/autobrr/go-qbittorrent/pkg/promtext"
+)
+
+const (
+	// DefaultExporterLabelLimit is the number of categories, tags or trackers exported by default
+	DefaultExporterLabelLimit = 50
+
+	// ExporterOtherLabel is the label value of the group that sums up the categories, tags or
+	// trackers beyond an exporter's limit
+	ExporterOtherLabel = "__other__"
+)
+
+// ExporterOptions configures an Exporter
+type ExporterOptions struct {
+	// MaxCategories, MaxTags and MaxTrackers bound the label values exported per instance for
+	// each dimension. The values with the most torrents are kept and the rest are summed up under
+	// ExporterOtherLabel. Zero uses DefaultExporterLabelLimit and a negative value skips the dimension.
+	MaxCategories int
+	MaxTags       int
+	MaxTrackers   int
+}
+
+// Exporter serves the server state and per-category, per-tag and per-tracker torrent aggregates
+// of one or more SyncManagers in the Prometheus text format. Every sample carries an instance label.
+// Uncategorised and untagged torrents, and torrents without a working tracker, are exported with
+// an empty label value.
+type Exporter struct {
+	options ExporterOptions
+
+	mu        sync.RWMutex
+	instances []exporterInstance
+}
+
+type exporterInstance struct {
+	name string
+	sm   *SyncManager
+}
+
+// exporterDimension is a grouping exported as qbittorrent_<name>_* metric families
+type exporterDimension struct {
+	name    string
+	label   string
+	groupBy string
+	limit   int
+}
+
+// exporterAggregate is a group of torrents, as exported for one label value
+type exporterAggregate struct {
+	value    string
+	count    int
+	size     float64
+	ratio    float64 // sum, averaged when rendered
+	seeders  float64
+	instance string
+}
+
+func NewExporter(options ExporterOptions) *Exporter {
+	return &Exporter{options: options}
+}
+
+// Add exports the data of sm under the given instance label.
+func (e *Exporter) Add(instance string, sm *SyncManager) *Exporter {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.instances = append(e.instances, exporterInstance{name: instance, sm: sm})
+	return e
+}
+
+// ServeHTTP renders the metrics of every instance in the Prometheus text exposition format
+func (e *Exporter) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	e.mu.RLock()
+	instances := slices.Clone(e.instances)
+	e.mu.RUnlock()
+
+	w.Header().Set("Content-Type", promtext.ContentType)
+
+	out := promtext.NewWriter(w)
+	e.writeServerState(out, instances)
+	for _, dim := range e.dimensions() {
+		writeExporterDimension(out, dim, instances)
+	}
+	_ = out.Flush() // the client went away
+}
+
+func (e *Exporter) dimensions() []exporterDimension {
+	dims := []exporterDimension{
+		{name: "category", label: "category", groupBy: "category", limit: e.options.MaxCategories},
+		{name: "tag", label: "tag", groupBy: AggregateByTag, limit: e.options.MaxTags},
+		{name: "tracker", label: "tracker", groupBy: AggregateByTrackerHost, limit: e.options.MaxTrackers},
+	}
+
+	for i := range dims {
+		if dims[i].limit == 0 {
+			dims[i].limit = DefaultExporterLabelLimit
+		}
+	}
+
+	return slices.DeleteFunc(dims, func(dim exporterDimension) bool { return dim.limit < 0 })
+}
+
+func (e *Exporter) writeServerState(w *promtext.Writer, instances []exporterInstance) {
+	states := make([]ServerState, len(instances))
+	for i, instance := range instances {
+		states[i] = instance.sm.GetServerState()
+	}
+
+	gauges := []struct {
+		name, help, typ string
+		value           func(s *ServerState) float64
+	}{
+		{"qbittorrent_download_speed_bytes", "Current download speed in bytes per second.", "gauge", func(s *ServerState) float64 { return float64(s.DlInfoSpeed) }},
+		{"qbittorrent_upload_speed_bytes", "Current upload speed in bytes per second.", "gauge", func(s *ServerState) float64 { return float64(s.UpInfoSpeed) }},
+		{"qbittorrent_download_rate_limit_bytes", "Global download rate limit in bytes per second, 0 if unlimited.", "gauge", func(s *ServerState) float64 { return float64(s.DlRateLimit) }},
+		{"qbittorrent_upload_rate_limit_bytes", "Global upload rate limit in bytes per second, 0 if unlimited.", "gauge", func(s *ServerState) float64 { return float64(s.UpRateLimit) }},
+		{"qbittorrent_downloaded_bytes_total", "Data downloaded over all sessions.", "counter", func(s *ServerState) float64 { return float64(s.AlltimeDl) }},
+		{"qbittorrent_uploaded_bytes_total", "Data uploaded over all sessions.", "counter", func(s *ServerState) float64 { return float64(s.AlltimeUl) }},
+		{"qbittorrent_session_downloaded_bytes", "Data downloaded in the current session.", "gauge", func(s *ServerState) float64 { return float64(s.DlInfoData) }},
+		{"qbittorrent_session_uploaded_bytes", "Data uploaded in the current session.", "gauge", func(s *ServerState) float64 { return float64(s.UpInfoData) }},
+		{"qbittorrent_dht_nodes", "Connected DHT nodes.", "gauge", func(s *ServerState) float64 { return float64(s.DhtNodes) }},
+		{"qbittorrent_peer_connections", "Open peer connections.", "gauge", func(s *ServerState) float64 { return float64(s.TotalPeerConnections) }},
+		{"qbittorrent_queued_io_jobs", "Disk jobs waiting to be processed.", "gauge", func(s *ServerState) float64 { return float64(s.QueuedIoJobs) }},
+		{"qbittorrent_free_space_bytes", "Free space in the default save path.", "gauge", func(s *ServerState) float64 { return float64(s.FreeSpaceOnDisk) }},
+		{"qbittorrent_connected", "Whether the client is connected to the internet (1) or firewalled or disconnected (0).", "gauge", func(s *ServerState) float64 {
+			if s.ConnectionStatus == "connected" {
+				return 1
+			}
+			return 0
+		}},
+	}
+
+	for _, gauge := range gauges {
+		w.Family(gauge.name, gauge.help, gauge.typ)
+		for i, instance := range instances {
+			w.Sample(gauge.name, []promtext.Label{{Name: "instance", Value: instance.name}}, gauge.value(&states[i]))
+		}
+	}
+}
+
+func writeExporterDimension(w *promtext.Writer, dim exporterDimension, instances []exporterInstance) {
+	var aggregates []exporterAggregate
+	for _, instance := range instances {
+		groups, err := instance.sm.AggregateTorrents(TorrentFilterOptions{}, TorrentAggregation{
+			GroupBy: []string{dim.groupBy},
+			Fields:  []string{"size", "ratio", "num_complete"},
+		})
+		if err != nil {
+			continue // the group keys and fields are fixed, so this cannot happen
+		}
+		aggregates = append(aggregates, limitExporterGroups(instance.name, groups, dim.limit)...)
+	}
+
+	prefix := "qbittorrent_" + dim.name + "_"
+	families := []struct {
+		name, help string
+		value      func(a *exporterAggregate) float64
+	}{
+		{"torrents", "Torrents by " + dim.name + ".", func(a *exporterAggregate) float64 { return float64(a.count) }},
+		{"size_bytes", "Selected size of the torrents by " + dim.name + ".", func(a *exporterAggregate) float64 { return a.size }},
+		{"ratio_average", "Average share ratio of the torrents by " + dim.name + ".", func(a *exporterAggregate) float64 { return a.ratio / float64(a.count) }},
+		{"seeders", "Seeders in the swarms of the torrents by " + dim.name + ".", func(a *exporterAggregate) float64 { return a.seeders }},
+	}
+
+	for _, family := range families {
+		w.Family(prefix+family.name, family.help, "gauge")
+		for i := range aggregates {
+			a := &aggregates[i]
+			w.Sample(prefix+family.name, []promtext.Label{{Name: "instance", Value: a.instance}, {Name: dim.label, Value: a.value}}, family.value(a))
+		}
+	}
+}
+
+// limitExporterGroups keeps the limit groups with the most torrents, ordered by label value,
+// and sums up the rest under ExporterOtherLabel
+func limitExporterGroups(instance string, groups []TorrentGroup, limit int) []exporterAggregate {
+	aggregates := make([]exporterAggregate, 0, len(groups))
+	for _, group := range groups {
+		aggregates = append(aggregates, exporterAggregate{
+			value:    group.Key[0],
+			count:    group.Count,
+			size:     group.Stats["size"].Sum,
+			ratio:    group.Stats["ratio"].Sum,
+			seeders:  group.Stats["num_complete"].Sum,
+			instance: instance,
+		})
+	}
+
+	if len(aggregates) <= limit {
+		return aggregates
+	}
+
+	slices.SortStableFunc(aggregates, func(a, b exporterAggregate) int { return cmp.Compare(b.count, a.count) })
+
+	other := exporterAggregate{value: ExporterOtherLabel, instance: instance}
+	for _, a := range aggregates[limit:] {
+		other.count += a.count
+		other.size += a.size
+		other.ratio += a.ratio
+		other.seeders += a.seeders
+	}
+
+	aggregates = aggregates[:limit]
+	slices.SortFunc(aggregates, func(a, b exporterAggregate) int { return cmp.Compare(a.value, b.value) })
+
+	return append(aggregates, other)
+}
@@ -0,0 +1,50 @@
+package qbittorrent
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/autobrr/go-qbittorrent/pkg/promtext"
+)
+
+func TestExporter(t *testing.T) {
+	sm := newSyncManagerWithBodies(`{"rid":1,"full_update":true,"torrents":{` +
+		`"aaa":{"category":"tv","tags":"hd, new","size":100,"ratio":1,"num_complete":5,"tracker":"https://t.example/announce"},` +
+		`"bbb":{"category":"tv","tags":"hd","size":50,"ratio":3,"num_complete":1,"tracker":"https://t.example/announce"},` +
+		`"ccc":{"category":"","tags":"","size":10,"ratio":0.5,"num_complete":2}},` +
+		`"server_state":{"dl_info_speed":1024,"alltime_ul":4096,"dht_nodes":300,"queued_io_jobs":2,"connection_status":"connected"}}`)
+	require.NoError(t, sm.Sync(context.Background()))
+
+	exporter := NewExporter(ExporterOptions{MaxTags: 1, MaxTrackers: -1}).Add("seedbox", sm)
+
+	rec := httptest.NewRecorder()
+	exporter.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Equal(t, promtext.ContentType, rec.Header().Get("Content-Type"))
+
+	body := rec.Body.String()
+	for _, line := range []string{
+		`qbittorrent_download_speed_bytes{instance="seedbox"} 1024`,
+		"# TYPE qbittorrent_uploaded_bytes_total counter",
+		`qbittorrent_uploaded_bytes_total{instance="seedbox"} 4096`,
+		`qbittorrent_dht_nodes{instance="seedbox"} 300`,
+		`qbittorrent_queued_io_jobs{instance="seedbox"} 2`,
+		`qbittorrent_connected{instance="seedbox"} 1`,
+		`qbittorrent_category_torrents{instance="seedbox",category=""} 1`,
+		`qbittorrent_category_torrents{instance="seedbox",category="tv"} 2`,
+		`qbittorrent_category_size_bytes{instance="seedbox",category="tv"} 150`,
+		`qbittorrent_category_ratio_average{instance="seedbox",category="tv"} 2`,
+		`qbittorrent_category_seeders{instance="seedbox",category="tv"} 6`,
+		`qbittorrent_tag_torrents{instance="seedbox",tag="hd"} 2`,
+		`qbittorrent_tag_torrents{instance="seedbox",tag="__other__"} 2`,
+		`qbittorrent_tag_size_bytes{instance="seedbox",tag="__other__"} 110`,
+	} {
+		assert.Contains(t, body, line+"\n")
+	}
+	assert.NotContains(t, body, "qbittorrent_tracker_")
+	assert.NotContains(t, body, `tag="new"`)
+}
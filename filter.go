@@ -5,6 +5,8 @@ package qbittorrent
 import (
 	"slices"
 	"strings"
+
+	"github.com/autobrr/go-qbittorrent/errors"
 )
 
 // removeDuplicateStrings removes duplicate strings from a slice and returns unique items
@@ -232,10 +234,54 @@ func matchesStateFilter(state TorrentState, filter TorrentFilter) bool {
 	return filter == TorrentFilterAll
 }
 
+// TorrentSortKey is one key of a multi-key sort
+type TorrentSortKey struct {
+	// Field is the Torrent JSON field name, e.g. "ratio"
+	Field string
+	// Desc sorts this key in descending order
+	Desc bool
+}
+
+// ParseTorrentSort parses a sort specification such as "state asc, ratio desc, name".
+// Keys without a direction sort ascending.
+func ParseTorrentSort(spec string) ([]TorrentSortKey, error) {
+	var keys []TorrentSortKey
+
+	for part := range strings.SplitSeq(spec, ",") {
+		words := strings.Fields(part)
+		if len(words) == 0 || len(words) > 2 {
+			return nil, errors.Wrap(ErrInvalidSortSpec, "invalid sort key %q", strings.TrimSpace(part))
+		}
+
+		key := TorrentSortKey{Field: words[0]}
+		if _, ok := torrentComparators[key.Field]; !ok || key.Field == "default" {
+			return nil, errors.Wrap(ErrInvalidSortSpec, "unknown sort field %q", key.Field)
+		}
+
+		if len(words) == 2 {
+			switch strings.ToLower(words[1]) {
+			case "asc":
+			case "desc":
+				key.Desc = true
+			default:
+				return nil, errors.Wrap(ErrInvalidSortSpec, "invalid sort direction %q", words[1])
+			}
+		}
+
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
 // applyTorrentFilterOptions applies sorting, reverse, limit, and offset to torrents
 func applyTorrentFilterOptions(torrents []Torrent, options TorrentFilterOptions) []Torrent {
 	// Sort
-	applyTorrentSorting(torrents, options.Sort, options.Reverse)
+	if len(options.SortKeys) > 0 {
+		applyTorrentSortKeys(torrents, options.SortKeys)
+	} else {
+		applyTorrentSorting(torrents, options.Sort, options.Reverse)
+	}
 
 	// Apply offset and limit
 	if options.Offset > 0 || options.Limit > 0 {
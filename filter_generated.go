@@ -534,6 +534,15 @@ func compareUpSpeed(a, b *Torrent) int {
 	return 0
 }
 
+func compareTrackers(a, b *Torrent) int {
+	if len(a.Trackers) < len(b.Trackers) {
+		return -1
+	} else if len(a.Trackers) > len(b.Trackers) {
+		return 1
+	}
+	return 0
+}
+
 func compareDefault(a, b *Torrent) int {
 	if a.Name == b.Name {
 		return 0
@@ -603,29 +612,61 @@ var torrentComparators = map[string]func(a, b *Torrent) int{
 	"uploaded": compareUploaded,
 	"uploaded_session": compareUploadedSession,
 	"upspeed": compareUpSpeed,
+	"trackers": compareTrackers,
 	"default": compareDefault,
 }
 
 // torrentSorter is a reusable struct for sorting torrents without allocations
 type torrentSorter struct {
-	torrents   []Torrent
-	comparator func(a, b *Torrent) int
-	reverse    bool
+	torrents    []Torrent
+	comparators []func(a, b *Torrent) int
+	desc        []bool
+}
+
+// newTorrentSorter resolves keys to comparators; unknown fields sort by name
+func newTorrentSorter(torrents []Torrent, keys []TorrentSortKey) *torrentSorter {
+	sorter := &torrentSorter{
+		torrents:    torrents,
+		comparators: make([]func(a, b *Torrent) int, len(keys)),
+		desc:        make([]bool, len(keys)),
+	}
+
+	for k, key := range keys {
+		comparator, exists := torrentComparators[key.Field]
+		if !exists {
+			comparator = torrentComparators["default"]
+		}
+		sorter.comparators[k] = comparator
+		sorter.desc[k] = key.Desc
+	}
+
+	return sorter
 }
 
 // compare is a static method that doesn't allocate
 func (s *torrentSorter) compare(i, j int) int {
-	result := s.comparator(&s.torrents[i], &s.torrents[j])
-	if result == 0 {
-		if s.torrents[i].Hash == s.torrents[j].Hash {
-			result = 0
-		} else if s.torrents[i].Hash < s.torrents[j].Hash {
-			result = -1
-		} else {
-			result = 1
-		} // secondary sort by hash for stability
-	}
-	if s.reverse {
+	return s.compareTorrents(&s.torrents[i], &s.torrents[j])
+}
+
+// compareTorrents orders two torrents by the sorter's keys, breaking ties by hash
+func (s *torrentSorter) compareTorrents(a, b *Torrent) int {
+	for k, comparator := range s.comparators {
+		if result := comparator(a, b); result != 0 {
+			if s.desc[k] {
+				return -result
+			}
+			return result
+		}
+	}
+
+	// final sort by hash, in the direction of the last key, so equal rows keep a deterministic order
+	result := 0
+	if a.Hash < b.Hash {
+		result = -1
+	} else if a.Hash > b.Hash {
+		result = 1
+	}
+	if s.desc[len(s.desc)-1] {
 		return -result
 	}
 	return result
@@ -636,25 +677,25 @@ func applyTorrentSorting(torrents []Torrent, sortField string, reverse bool) {
 		return
 	}
 
-	comparator, exists := torrentComparators[sortField]
-	if !exists {
-		comparator = torrentComparators["default"]
+	applyTorrentSortKeys(torrents, []TorrentSortKey{{Field: sortField, Desc: reverse}})
+}
+
+// applyTorrentSortKeys stably sorts torrents by each key in turn; unknown fields sort by name
+func applyTorrentSortKeys(torrents []Torrent, keys []TorrentSortKey) {
+	if len(keys) == 0 {
+		return
 	}
 
+	sorter := newTorrentSorter(torrents, keys)
+
 	// Create indices to sort instead of large structs
 	indices := make([]int, len(torrents))
 	for i := range indices {
 		indices[i] = i
 	}
 
-	sorter := &torrentSorter{
-		torrents:  torrents,
-		comparator: comparator,
-		reverse:   reverse,
-	}
-
 	// Sort indices using the static method - no allocation!
-	slices.SortFunc(indices, sorter.compare)
+	slices.SortStableFunc(indices, sorter.compare)
 
 	// Apply permutation in place using cycle decomposition
 	for i := 0; i < len(torrents); i++ {
@@ -4,7 +4,6 @@ go 1.25.0
 
 require (
 	github.com/Masterminds/semver v1.5.0
-	github.com/avast/retry-go v3.0.0+incompatible
 	github.com/pkg/errors v0.9.1
 	github.com/stretchr/testify v1.11.1
 	golang.org/x/exp v0.0.0-20251113190631-e25ba8c21ef6
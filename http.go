@@ -6,15 +6,11 @@ import (
 	"io"
 	"math/rand"
 	"mime/multipart"
-	"net"
 	"net/http"
 	"net/url"
-	"os"
 	"strings"
-	"time"
 
 	"github.com/autobrr/go-qbittorrent/errors"
-	"github.com/avast/retry-go"
 )
 
 func (c *Client) getCtx(ctx context.Context, endpoint string, opts map[string]string) (*http.Response, error) {
@@ -40,7 +36,7 @@ func (c *Client) getCtx(ctx context.Context, endpoint string, opts map[string]st
 	}
 
 	// try request and if fail run 10 retries
-	resp, err := c.retryDo(ctx, req)
+	resp, err := c.send(ctx, &Request{Endpoint: endpoint, Method: http.MethodGet, Params: opts, HTTP: req}, c.retryHandler)
 	if err != nil {
 		return nil, errors.Wrap(err, "error making get request: %v", reqUrl)
 	}
@@ -80,7 +76,7 @@ func (c *Client) postCtx(ctx context.Context, endpoint string, opts map[string]s
 	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
 
 	// try request and if fail run 10 retries
-	resp, err := c.retryDo(ctx, req)
+	resp, err := c.send(ctx, &Request{Endpoint: endpoint, Method: http.MethodPost, Params: opts, HTTP: req}, c.retryHandler)
 	if err != nil {
 		return nil, errors.Wrap(err, "error making post request: %v", reqUrl)
 	}
@@ -112,7 +108,9 @@ func (c *Client) postBasicCtx(ctx context.Context, endpoint string, opts map[str
 	// add the content-type so qbittorrent knows what to expect
 	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
 
-	resp, err := c.http.Do(req)
+	resp, err := c.send(ctx, &Request{Endpoint: endpoint, Method: http.MethodPost, Params: opts, HTTP: req}, func(_ context.Context, req *Request) (*http.Response, error) {
+		return c.http.Do(req.HTTP)
+	})
 	if err != nil {
 		return nil, errors.Wrap(err, "error making post request: %v", reqUrl)
 	}
@@ -120,85 +118,10 @@ func (c *Client) postBasicCtx(ctx context.Context, endpoint string, opts map[str
 	return resp, nil
 }
 
-func (c *Client) postFileCtx(ctx context.Context, endpoint string, fileName string, opts map[string]string) (*http.Response, error) {
-	file, err := os.Open(fileName)
-	if err != nil {
-		return nil, errors.Wrap(err, "error opening file %v", fileName)
-	}
-	defer file.Close()
-
-	return c.postReaderCtx(ctx, endpoint, file, opts)
-}
-
 func (c *Client) postMemoryCtx(ctx context.Context, endpoint string, buf []byte, opts map[string]string) (*http.Response, error) {
 	return c.postReaderCtx(ctx, endpoint, bytes.NewReader(buf), opts)
 }
 
-// buildMultiFileForm creates a multipart form with multiple torrent files and options.
-func buildMultiFileForm(files [][]byte, opts map[string]string) (*bytes.Buffer, string, error) {
-	var body bytes.Buffer
-	writer := multipart.NewWriter(&body)
-
-	for _, fileContent := range files {
-		fw, err := writer.CreateFormFile("torrents", generateTorrentName())
-		if err != nil {
-			return nil, "", errors.Wrap(err, "error initializing file field")
-		}
-		if _, err := fw.Write(fileContent); err != nil {
-			return nil, "", errors.Wrap(err, "error writing file contents")
-		}
-	}
-
-	for key, val := range opts {
-		if err := writer.WriteField(key, val); err != nil {
-			return nil, "", errors.Wrap(err, "error writing field %v", key)
-		}
-	}
-
-	contentType := writer.FormDataContentType()
-	writer.Close()
-
-	return &body, contentType, nil
-}
-
-// postMultiMemoryCtx sends multiple torrent files in a single multipart request.
-// qBittorrent supports multiple "torrents" form fields in one request.
-func (c *Client) postMultiMemoryCtx(ctx context.Context, endpoint string, files [][]byte, opts map[string]string) (*http.Response, error) {
-	body, contentType, err := buildMultiFileForm(files, opts)
-	if err != nil {
-		return nil, err
-	}
-
-	reqUrl := c.buildUrl(endpoint, nil)
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, reqUrl, body)
-	if err != nil {
-		return nil, errors.Wrap(err, "error creating request")
-	}
-
-	if c.cfg.BasicUser != "" && c.cfg.BasicPass != "" {
-		req.SetBasicAuth(c.cfg.BasicUser, c.cfg.BasicPass)
-	}
-	if c.usingAPIKeyAuth() {
-		c.setAPIKeyAuthHeader(req)
-	} else {
-		cookieURL, _ := url.Parse(c.buildUrl("/", nil)) //nolint:errcheck // buildUrl returns valid URL
-		if len(c.http.Jar.Cookies(cookieURL)) == 0 {
-			if loginErr := c.LoginCtx(ctx); loginErr != nil {
-				return nil, errors.Wrap(loginErr, "qbit re-login failed")
-			}
-		}
-	}
-
-	req.Header.Set("Content-Type", contentType)
-
-	resp, err := c.retryDo(ctx, req)
-	if err != nil {
-		return nil, errors.Wrap(err, "error making post multi-file request")
-	}
-
-	return resp, nil
-}
-
 func (c *Client) postReaderCtx(ctx context.Context, endpoint string, reader io.Reader, opts map[string]string) (*http.Response, error) {
 	// Buffer to store our request body as bytes
 	var requestBody bytes.Buffer
@@ -257,7 +180,7 @@ func (c *Client) postReaderCtx(ctx context.Context, endpoint string, reader io.R
 	// Set correct content type
 	req.Header.Set("Content-Type", contentType)
 
-	resp, err := c.retryDo(ctx, req)
+	resp, err := c.send(ctx, &Request{Endpoint: endpoint, Method: http.MethodPost, Params: opts, HTTP: req}, c.retryHandler)
 	if err != nil {
 		return nil, errors.Wrap(err, "error making post file request")
 	}
@@ -334,106 +257,3 @@ func drainAndClose(resp *http.Response) {
 		resp.Body.Close()
 	}
 }
-
-func isClosedConnectionError(err error) bool {
-	if err == nil {
-		return false
-	}
-
-	// Check for unexpected EOF during request (not response body read)
-	// http.Do can return this if connection dies during headers
-	if err == io.ErrUnexpectedEOF {
-		return true
-	}
-
-	// Check for common connection error strings
-	errStr := err.Error()
-	connectionErrors := []string{
-		"use of closed network connection",
-		"broken pipe",
-		"connection reset by peer",
-		"connection refused",
-	}
-
-	for _, connErr := range connectionErrors {
-		if strings.Contains(errStr, connErr) {
-			return true
-		}
-	}
-
-	// Check for net.OpError with connection issues
-	var opErr *net.OpError
-	return errors.As(err, &opErr)
-}
-
-func (c *Client) retryDo(ctx context.Context, req *http.Request) (*http.Response, error) {
-	var (
-		originalBody []byte
-		err          error
-	)
-
-	if req != nil && req.Body != nil {
-		originalBody, err = copyBody(req.Body)
-	}
-
-	if err != nil {
-		return nil, err
-	}
-
-	var resp *http.Response
-
-	// try request and if fail run 10 retries
-	err = retry.Do(func() error {
-		if req != nil && req.Body != nil {
-			resetBody(req, originalBody)
-		}
-
-		resp, err = c.http.Do(req)
-
-		if err != nil {
-			if err == context.DeadlineExceeded || err == context.Canceled {
-				return retry.Unrecoverable(err)
-			}
-
-			// Handle closed/dead connections immediately without delay
-			// These are internal connection pool issues that should be retried fast
-			if isClosedConnectionError(err) {
-				// Clean up stale connections in the pool
-				c.http.CloseIdleConnections()
-				return err
-			}
-
-			retry.Delay(c.retryDelay)
-			return err
-		}
-
-		if resp.StatusCode == http.StatusForbidden {
-			drainAndClose(resp)
-			if c.usingAPIKeyAuth() {
-				return nil
-			}
-			if err := c.LoginCtx(ctx); err != nil {
-				return errors.Wrap(err, "qbit re-login failed")
-			}
-			retry.Delay(100 * time.Millisecond)
-			return errors.New("qbit re-login")
-		} else if resp.StatusCode < 500 {
-			return nil
-		} else if resp.StatusCode >= 500 {
-			drainAndClose(resp)
-			return retry.Unrecoverable(errors.New("unrecoverable status: %v", resp.StatusCode))
-		}
-
-		return nil
-	},
-		retry.OnRetry(func(n uint, err error) { c.log.Printf("%q: attempt %d - %v\n", err, n, req.URL.String()) }),
-		retry.Attempts(uint(c.retryAttempts)),
-		retry.MaxJitter(time.Second*1),
-	)
-
-	if err != nil {
-		return nil, errors.Wrap(err, "error making request")
-	}
-
-	return resp, nil
-}
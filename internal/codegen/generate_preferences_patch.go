@@ -0,0 +1,187 @@
+//go:build generate
+
+// Package main contains code generators for the go-qbittorrent project.
+// This generator creates typed setters for PreferencesPatch and the DiffPreferences function.
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"log"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+type PreferenceFieldInfo struct {
+	Name    string
+	JSONTag string
+	Type    string
+}
+
+func main() {
+	// Parse the domain.go file to extract the AppPreferences struct
+	fset := token.NewFileSet()
+	domainFile := "domain.go"
+	file, err := parser.ParseFile(fset, domainFile, nil, parser.ParseComments)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var fields []PreferenceFieldInfo
+
+	ast.Inspect(file, func(n ast.Node) bool {
+		if ts, ok := n.(*ast.TypeSpec); ok && ts.Name.Name == "AppPreferences" {
+			if st, ok := ts.Type.(*ast.StructType); ok {
+				fields = parsePreferenceFields(st)
+			}
+		}
+		return true
+	})
+
+	if len(fields) == 0 {
+		log.Fatal("No AppPreferences struct found")
+	}
+
+	generatePreferencesPatchFile(fields)
+}
+
+func parsePreferenceFields(st *ast.StructType) []PreferenceFieldInfo {
+	var fields []PreferenceFieldInfo
+
+	for _, field := range st.Fields.List {
+		if len(field.Names) == 0 || field.Tag == nil {
+			continue // Skip embedded and untagged fields
+		}
+
+		fieldName := field.Names[0].Name
+		if !ast.IsExported(fieldName) {
+			continue
+		}
+
+		tagValue, err := strconv.Unquote(field.Tag.Value)
+		if err != nil {
+			log.Fatalf("invalid tag on AppPreferences.%s: %v", fieldName, err)
+		}
+
+		jsonTag, _, _ := strings.Cut(reflect.StructTag(tagValue).Get("json"), ",")
+		if jsonTag == "" || jsonTag == "-" {
+			continue
+		}
+
+		fields = append(fields, PreferenceFieldInfo{
+			Name:    fieldName,
+			JSONTag: jsonTag,
+			Type:    getPreferenceTypeString(field.Type),
+		})
+	}
+
+	return fields
+}
+
+func getPreferenceTypeString(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		return t.Name
+	case *ast.StarExpr:
+		return "*" + getPreferenceTypeString(t.X)
+	case *ast.ArrayType:
+		return "[]" + getPreferenceTypeString(t.Elt)
+	case *ast.MapType:
+		return "map[" + getPreferenceTypeString(t.Key) + "]" + getPreferenceTypeString(t.Value)
+	case *ast.SelectorExpr:
+		return getPreferenceTypeString(t.X) + "." + t.Sel.Name
+	case *ast.InterfaceType:
+		return "interface{}"
+	default:
+		return "interface{}"
+	}
+}
+
+// inequalityExpr returns an expression reporting whether field differs between a and b,
+// along with the packages that expression needs.
+func inequalityExpr(field PreferenceFieldInfo) (string, []string) {
+	switch {
+	case field.Type == "interface{}":
+		return fmt.Sprintf("!reflect.DeepEqual(a.%s, b.%s)", field.Name, field.Name), []string{"reflect"}
+	case field.Type == "MonitoredFolders", strings.HasPrefix(field.Type, "map["):
+		return fmt.Sprintf("!maps.Equal(a.%s, b.%s)", field.Name, field.Name), []string{"maps"}
+	case strings.HasPrefix(field.Type, "[]"):
+		return fmt.Sprintf("!slices.Equal(a.%s, b.%s)", field.Name, field.Name), []string{"slices"}
+	default:
+		return fmt.Sprintf("a.%s != b.%s", field.Name, field.Name), nil
+	}
+}
+
+func generatePreferencesPatchFile(fields []PreferenceFieldInfo) {
+	var body strings.Builder
+	imports := map[string]bool{}
+
+	for _, field := range fields {
+		fmt.Fprintf(&body, `// Set%s sets the %s preference (AppPreferences.%s).
+func (p *PreferencesPatch) Set%s(v %s) *PreferencesPatch {
+	return p.set(%q, v)
+}
+
+`, field.Name, field.JSONTag, field.Name, field.Name, field.Type, field.JSONTag)
+	}
+
+	body.WriteString(`// DiffPreferences returns the minimal patch that turns a into b.
+// Only fields whose values differ are included, set to their value in b.
+func DiffPreferences(a, b AppPreferences) *PreferencesPatch {
+	p := NewPreferencesPatch()
+
+`)
+
+	for _, field := range fields {
+		expr, pkgs := inequalityExpr(field)
+		for _, pkg := range pkgs {
+			imports[pkg] = true
+		}
+
+		fmt.Fprintf(&body, `	if %s {
+		p.set(%q, b.%s)
+	}
+`, expr, field.JSONTag, field.Name)
+	}
+
+	body.WriteString(`
+	return p
+}
+`)
+
+	var output strings.Builder
+	output.WriteString(`// Code generated by go generate; DO NOT EDIT.
+// This file was generated by internal/codegen/generate_preferences_patch.go
+
+package qbittorrent
+`)
+
+	if len(imports) > 0 {
+		output.WriteString("\nimport (\n")
+		for _, pkg := range []string{"maps", "reflect", "slices"} {
+			if imports[pkg] {
+				fmt.Fprintf(&output, "\t%q\n", pkg)
+			}
+		}
+		output.WriteString(")\n")
+	}
+
+	output.WriteString("\n")
+	output.WriteString(body.String())
+
+	formatted, err := format.Source([]byte(output.String()))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := os.WriteFile("preferences_patch_generated.go", formatted, 0644); err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Printf("Generated preferences_patch_generated.go with %d preference fields\n", len(fields))
+}
@@ -177,6 +177,17 @@ import (
 	return 1
 }
 
+`, field.Name, field.Name, field.Name, field.Name, field.Name)
+		} else if isSliceType(field.Type) {
+			output += fmt.Sprintf(`func compare%s(a, b *Torrent) int {
+	if len(a.%s) < len(b.%s) {
+		return -1
+	} else if len(a.%s) > len(b.%s) {
+		return 1
+	}
+	return 0
+}
+
 `, field.Name, field.Name, field.Name, field.Name, field.Name)
 		}
 	}
@@ -197,7 +208,7 @@ var torrentComparators = map[string]func(a, b *Torrent) int{
 
 	// Generate map entries
 	for _, field := range fields {
-		if field.Type == "bool" || isComparableType(field.Type) || field.Type == "TorrentState" {
+		if isSortableType(field.Type) {
 			output += fmt.Sprintf(`	"%s": compare%s,
 `, field.JSONTag, field.Name)
 		}
@@ -208,24 +219,55 @@ var torrentComparators = map[string]func(a, b *Torrent) int{
 
 // torrentSorter is a reusable struct for sorting torrents without allocations
 type torrentSorter struct {
-	torrents   []Torrent
-	comparator func(a, b *Torrent) int
-	reverse    bool
+	torrents    []Torrent
+	comparators []func(a, b *Torrent) int
+	desc        []bool
+}
+
+// newTorrentSorter resolves keys to comparators; unknown fields sort by name
+func newTorrentSorter(torrents []Torrent, keys []TorrentSortKey) *torrentSorter {
+	sorter := &torrentSorter{
+		torrents:    torrents,
+		comparators: make([]func(a, b *Torrent) int, len(keys)),
+		desc:        make([]bool, len(keys)),
+	}
+
+	for k, key := range keys {
+		comparator, exists := torrentComparators[key.Field]
+		if !exists {
+			comparator = torrentComparators["default"]
+		}
+		sorter.comparators[k] = comparator
+		sorter.desc[k] = key.Desc
+	}
+
+	return sorter
 }
 
 // compare is a static method that doesn't allocate
 func (s *torrentSorter) compare(i, j int) int {
-	result := s.comparator(&s.torrents[i], &s.torrents[j])
-	if result == 0 {
-		if s.torrents[i].Hash == s.torrents[j].Hash {
-			result = 0
-		} else if s.torrents[i].Hash < s.torrents[j].Hash {
-			result = -1
-		} else {
-			result = 1
-		} // secondary sort by hash for stability
+	return s.compareTorrents(&s.torrents[i], &s.torrents[j])
+}
+
+// compareTorrents orders two torrents by the sorter's keys, breaking ties by hash
+func (s *torrentSorter) compareTorrents(a, b *Torrent) int {
+	for k, comparator := range s.comparators {
+		if result := comparator(a, b); result != 0 {
+			if s.desc[k] {
+				return -result
+			}
+			return result
+		}
+	}
+
+	// final sort by hash, in the direction of the last key, so equal rows keep a deterministic order
+	result := 0
+	if a.Hash < b.Hash {
+		result = -1
+	} else if a.Hash > b.Hash {
+		result = 1
 	}
-	if s.reverse {
+	if s.desc[len(s.desc)-1] {
 		return -result
 	}
 	return result
@@ -236,25 +278,25 @@ func applyTorrentSorting(torrents []Torrent, sortField string, reverse bool) {
 		return
 	}
 
-	comparator, exists := torrentComparators[sortField]
-	if !exists {
-		comparator = torrentComparators["default"]
+	applyTorrentSortKeys(torrents, []TorrentSortKey{{Field: sortField, Desc: reverse}})
+}
+
+// applyTorrentSortKeys stably sorts torrents by each key in turn; unknown fields sort by name
+func applyTorrentSortKeys(torrents []Torrent, keys []TorrentSortKey) {
+	if len(keys) == 0 {
+		return
 	}
 
+	sorter := newTorrentSorter(torrents, keys)
+
 	// Create indices to sort instead of large structs
 	indices := make([]int, len(torrents))
 	for i := range indices {
 		indices[i] = i
 	}
 
-	sorter := &torrentSorter{
-		torrents:  torrents,
-		comparator: comparator,
-		reverse:   reverse,
-	}
-
 	// Sort indices using the static method - no allocation!
-	slices.SortFunc(indices, sorter.compare)
+	slices.SortStableFunc(indices, sorter.compare)
 
 	// Apply permutation in place using cycle decomposition
 	for i := 0; i < len(torrents); i++ {
@@ -283,7 +325,24 @@ func applyTorrentSorting(torrents []Torrent, sortField string, reverse bool) {
 		log.Fatal(err)
 	}
 
-	fmt.Printf("Generated filter_generated.go with %d sortable fields\n", len(fields))
+	sortable := 0
+	for _, field := range fields {
+		if isSortableType(field.Type) {
+			sortable++
+		}
+	}
+
+	fmt.Printf("Generated filter_generated.go with %d sortable fields\n", sortable)
+}
+
+// isSortableType reports whether a comparator is generated for fields of goType
+func isSortableType(goType string) bool {
+	return goType == "bool" || goType == "TorrentState" || isComparableType(goType) || isSliceType(goType)
+}
+
+// isSliceType reports whether goType is a slice; slices are sorted by length
+func isSliceType(goType string) bool {
+	return strings.HasPrefix(goType, "[]")
 }
 
 func isComparableType(goType string) bool {
@@ -0,0 +1,138 @@
+//go:build generate
+
+// Package main contains code generators for the go-qbittorrent project.
+// This generator creates the field table used by the torrent query language.
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"log"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+type QueryFieldInfo struct {
+	Name    string
+	JSONTag string
+	Type    string
+}
+
+func main() {
+	// Parse the domain.go file to extract the Torrent struct
+	fset := token.NewFileSet()
+	domainFile := "domain.go"
+	file, err := parser.ParseFile(fset, domainFile, nil, parser.ParseComments)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var fields []QueryFieldInfo
+
+	ast.Inspect(file, func(n ast.Node) bool {
+		if ts, ok := n.(*ast.TypeSpec); ok && ts.Name.Name == "Torrent" {
+			if st, ok := ts.Type.(*ast.StructType); ok {
+				fields = parseQueryFields(st)
+			}
+		}
+		return true
+	})
+
+	if len(fields) == 0 {
+		log.Fatal("No Torrent struct found")
+	}
+
+	generateQueryFieldsFile(fields)
+}
+
+func parseQueryFields(st *ast.StructType) []QueryFieldInfo {
+	var fields []QueryFieldInfo
+
+	for _, field := range st.Fields.List {
+		if len(field.Names) == 0 || field.Tag == nil {
+			continue // Skip embedded and untagged fields
+		}
+
+		fieldName := field.Names[0].Name
+		if !ast.IsExported(fieldName) {
+			continue
+		}
+
+		tagValue, err := strconv.Unquote(field.Tag.Value)
+		if err != nil {
+			log.Fatalf("invalid tag on Torrent.%s: %v", fieldName, err)
+		}
+
+		jsonTag, _, _ := strings.Cut(reflect.StructTag(tagValue).Get("json"), ",")
+		if jsonTag == "" || jsonTag == "-" {
+			continue
+		}
+
+		ident, ok := field.Type.(*ast.Ident)
+		if !ok {
+			continue // Slices and other composite types cannot be queried
+		}
+
+		fields = append(fields, QueryFieldInfo{
+			Name:    fieldName,
+			JSONTag: jsonTag,
+			Type:    ident.Name,
+		})
+	}
+
+	return fields
+}
+
+// queryFieldEntry returns the map entry for field, or "" if its type cannot be queried
+func queryFieldEntry(field QueryFieldInfo) string {
+	switch field.Type {
+	case "string":
+		return fmt.Sprintf("%q: {kind: queryKindString, str: func(t *Torrent) string { return t.%s }},\n", field.JSONTag, field.Name)
+	case "TorrentState":
+		return fmt.Sprintf("%q: {kind: queryKindString, str: func(t *Torrent) string { return string(t.%s) }},\n", field.JSONTag, field.Name)
+	case "bool":
+		return fmt.Sprintf("%q: {kind: queryKindBool, boolean: func(t *Torrent) bool { return t.%s }},\n", field.JSONTag, field.Name)
+	case "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64", "float32", "float64":
+		return fmt.Sprintf("%q: {kind: queryKindNumber, number: func(t *Torrent) float64 { return float64(t.%s) }},\n", field.JSONTag, field.Name)
+	default:
+		return ""
+	}
+}
+
+func generateQueryFieldsFile(fields []QueryFieldInfo) {
+	var output strings.Builder
+	output.WriteString(`// Code generated by go generate; DO NOT EDIT.
+// This file was generated by internal/codegen/generate_torrent_query.go
+
+package qbittorrent
+
+// torrentQueryFields maps Torrent JSON field names to their query accessors
+var torrentQueryFields = map[string]queryField{
+`)
+
+	count := 0
+	for _, field := range fields {
+		if entry := queryFieldEntry(field); entry != "" {
+			output.WriteString(entry)
+			count++
+		}
+	}
+
+	output.WriteString("}\n")
+
+	formatted, err := format.Source([]byte(output.String()))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := os.WriteFile("query_fields_generated.go", formatted, 0644); err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Printf("Generated query_fields_generated.go with %d queryable fields\n", count)
+}
@@ -0,0 +1,136 @@
+package qbittorrent
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"regexp"
+	"time"
+)
+
+// maxLoggedBodySize caps how much of a response body is logged when Config.LogBodies is set
+const maxLoggedBodySize = 64 << 10
+
+const redacted = "[REDACTED]"
+
+var (
+	// sensitiveKey matches parameter and JSON keys whose values must never be logged
+	sensitiveKey = regexp.MustCompile(`(?i)pass(word|wd)?|secret|token|api_?key|cookie|auth`)
+	// sensitiveJSONField matches a JSON string field with a sensitive key, e.g. "web_ui_password":"x"
+	sensitiveJSONField = regexp.MustCompile(`"([^"]*(?i:pass(?:word|wd)?|secret|token|api_?key|cookie|auth)[^"]*)"(\s*):(\s*)"(?:[^"\\]|\\.)*"`)
+)
+
+var discardLogger = slog.New(slog.DiscardHandler)
+
+// slogger returns Config.Logger, or a logger that discards everything
+func (c *Client) slogger() *slog.Logger {
+	if c.logger == nil {
+		return discardLogger
+	}
+	return c.logger
+}
+
+// logRequest sends req through next and logs its outcome with the endpoint, method, host,
+// torrent hash, status and duration. Successful requests are logged at debug level.
+func (c *Client) logRequest(ctx context.Context, req *Request, next RequestHandler) (*http.Response, error) {
+	attrs := requestAttrs(req)
+	logger := c.slogger()
+	debug := logger.Enabled(ctx, slog.LevelDebug)
+	if debug && c.cfg.LogBodies {
+		logger.LogAttrs(ctx, slog.LevelDebug, "sending request", append(attrs, slog.Any("params", redactParams(req.Params)))...)
+	}
+
+	start := time.Now()
+	resp, err := next(ctx, req)
+	attrs = append(attrs, slog.Duration("duration", time.Since(start)))
+
+	if err != nil {
+		logger.LogAttrs(ctx, slog.LevelError, "request failed", append(attrs, slog.Any("error", err))...)
+		return resp, err
+	}
+
+	attrs = append(attrs, slog.Int("status", resp.StatusCode))
+	if debug && c.cfg.LogBodies {
+		attrs = append(attrs, slog.String("body", peekBody(resp)))
+	}
+
+	level := slog.LevelDebug
+	if resp.StatusCode >= http.StatusBadRequest {
+		level = slog.LevelWarn
+	}
+	logger.LogAttrs(ctx, level, "request completed", attrs...)
+
+	return resp, nil
+}
+
+// requestAttrs returns the attributes shared by every log record about req
+func requestAttrs(req *Request) []slog.Attr {
+	attrs := []slog.Attr{
+		slog.String("endpoint", req.Endpoint),
+		slog.String("method", req.Method),
+	}
+	if req.HTTP != nil {
+		attrs = append(attrs, slog.String("host", req.HTTP.URL.Host))
+	}
+	if hash := paramsHash(req.Params); hash != "" {
+		attrs = append(attrs, slog.String("hash", hash))
+	}
+
+	return attrs
+}
+
+// paramsHash returns the torrent hash, or hashes, a request applies to
+func paramsHash(params map[string]string) string {
+	if hash := params["hash"]; hash != "" {
+		return hash
+	}
+	return params["hashes"]
+}
+
+// hostAttr returns the host attribute for records that are not about a single request
+func (c *Client) hostAttr() slog.Attr {
+	host := c.cfg.Host
+	if u, err := url.Parse(c.buildUrl("/", nil)); err == nil {
+		host = u.Host
+	}
+	return slog.String("host", host)
+}
+
+func redactParams(params map[string]string) map[string]string {
+	out := make(map[string]string, len(params))
+	for key, val := range params {
+		if sensitiveKey.MatchString(key) {
+			val = redacted
+		} else {
+			val = redactJSON(val)
+		}
+		out[key] = val
+	}
+	return out
+}
+
+// redactJSON replaces the values of sensitive string fields in a JSON document
+func redactJSON(s string) string {
+	return sensitiveJSONField.ReplaceAllString(s, `"$1"$2:$3"`+redacted+`"`)
+}
+
+// peekBody returns the start of a response body, redacted, without consuming it
+func peekBody(resp *http.Response) string {
+	if resp.Body == nil || resp.Body == http.NoBody {
+		return ""
+	}
+
+	head, err := io.ReadAll(io.LimitReader(resp.Body, maxLoggedBodySize))
+	resp.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(head), resp.Body), resp.Body}
+	if err != nil {
+		return ""
+	}
+
+	return redactJSON(string(head))
+}
@@ -0,0 +1,93 @@
+package qbittorrent
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func decodeLogRecords(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+
+	var records []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var record map[string]any
+		require.NoError(t, json.Unmarshal([]byte(line), &record))
+		records = append(records, record)
+	}
+	return records
+}
+
+func TestClient_Logger(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/auth/login":
+			http.SetCookie(w, &http.Cookie{Name: "SID", Value: "sid"})
+			_, _ = w.Write([]byte("Ok."))
+		case "/api/v2/torrents/pieceStates":
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"web_ui_username":"admin","web_ui_password": "hunter2","proxy_password":"p\"w"}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	var buf bytes.Buffer
+	client := NewClient(Config{
+		Host:      server.URL,
+		Username:  "admin",
+		Password:  "hunter2",
+		Logger:    slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})),
+		LogBodies: true,
+	})
+
+	_, err := client.GetAppPreferences()
+	require.NoError(t, err)
+	_, err = client.GetTorrentPieceStates("abc")
+	require.Error(t, err)
+
+	assert.NotContains(t, buf.String(), "hunter2")
+	assert.NotContains(t, buf.String(), `p\"w`)
+
+	records := decodeLogRecords(t, &buf)
+	var login, prefs, pieces map[string]any
+	for _, record := range records {
+		switch {
+		case record["msg"] == "sending request" && record["endpoint"] == "auth/login":
+			login = record
+		case record["msg"] == "request completed" && record["endpoint"] == "app/preferences":
+			prefs = record
+		case record["msg"] == "request completed" && record["endpoint"] == "torrents/pieceStates":
+			pieces = record
+		}
+	}
+
+	require.NotNil(t, login)
+	assert.Equal(t, map[string]any{"username": "admin", "password": redacted}, login["params"])
+
+	require.NotNil(t, prefs)
+	assert.Equal(t, "DEBUG", prefs["level"])
+	assert.Equal(t, http.MethodGet, prefs["method"])
+	assert.Equal(t, strings.TrimPrefix(server.URL, "http://"), prefs["host"])
+	assert.EqualValues(t, http.StatusOK, prefs["status"])
+	assert.Contains(t, prefs, "duration")
+	assert.Contains(t, prefs["body"], `"web_ui_username":"admin"`)
+
+	require.NotNil(t, pieces)
+	assert.Equal(t, "WARN", pieces["level"])
+	assert.Equal(t, "abc", pieces["hash"])
+}
+
+func TestRedactJSON(t *testing.T) {
+	assert.Equal(t,
+		`{"web_ui_password":"[REDACTED]","api_key" : "[REDACTED]","save_path":"/data","web_ui_secure_cookie_enabled":true}`,
+		redactJSON(`{"web_ui_password":"x\"y","api_key" : "abc","save_path":"/data","web_ui_secure_cookie_enabled":true}`))
+}
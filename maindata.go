@@ -4,6 +4,8 @@ package qbittorrent
 
 import (
 	"context"
+	"maps"
+	"slices"
 )
 
 // normalizeHash sets .Hash from InfohashV1 or InfohashV2 if Hash is empty
@@ -45,26 +47,43 @@ func (dest *MainData) ensureInitialized() {
 }
 
 func (dest *MainData) Update(ctx context.Context, c *Client) error {
+	return dest.update(ctx, c, nil)
+}
+
+// update syncs dest and records the resulting changes into rec, which may be nil
+func (dest *MainData) update(ctx context.Context, c *Client, rec *syncEventRecorder) error {
 	source, rawData, err := c.SyncMainDataCtxWithRaw(ctx, int64(dest.Rid))
 	if err != nil {
 		return err
 	}
 
+	dest.apply(source, rawData, rec)
+	return nil
+}
+
+// apply merges a sync/maindata response requested with dest's rid into dest
+func (dest *MainData) apply(source *MainData, rawData map[string]interface{}, rec *syncEventRecorder) {
 	// If this is a partial update (FullUpdate is false), use UpdateWithRawData
 	if !source.FullUpdate {
-		dest.UpdateWithRawData(rawData, source)
-		return nil
+		dest.updateWithRawData(rawData, source, rec)
+		return
 	}
 
 	// For full updates, replace everything
+	prev := *dest
 	*dest = *source
 	dest.ensureInitialized()
-	return nil
+	rec.full()
+	rec.diff(&prev, dest)
 }
 
 // UpdateWithRawData efficiently merges partial updates using raw JSON data
 // This provides field-level merging similar to the SyncManager's logic
 func (dest *MainData) UpdateWithRawData(rawData map[string]interface{}, source *MainData) {
+	dest.updateWithRawData(rawData, source, nil)
+}
+
+func (dest *MainData) updateWithRawData(rawData map[string]interface{}, source *MainData, rec *syncEventRecorder) {
 	dest.ensureInitialized()
 
 	// Update RID
@@ -74,22 +93,35 @@ func (dest *MainData) UpdateWithRawData(rawData map[string]interface{}, source *
 	// This prevents clearing torrents when there's no torrent update
 	if torrentsRaw, exists := rawData["torrents"]; exists {
 		if torrentsMap, ok := torrentsRaw.(map[string]interface{}); ok {
-			dest.mergeTorrentsPartial(torrentsMap)
+			dest.mergeTorrentsPartial(torrentsMap, rec)
 		}
 	}
 
 	// Remove deleted torrents ONLY if there are actually items to remove
 	if len(source.TorrentsRemoved) > 0 {
+		for _, hash := range source.TorrentsRemoved {
+			rec.touch(hash)
+			if torrent, exists := dest.Torrents[hash]; exists {
+				rec.add(TorrentRemoved{Hash: hash, Torrent: torrent})
+			}
+		}
 		remove(source.TorrentsRemoved, &dest.Torrents)
 	}
 
 	// Handle categories ONLY if present in raw JSON
 	if categoriesRaw, exists := rawData["categories"]; exists {
 		if categoriesMap, ok := categoriesRaw.(map[string]interface{}); ok {
-			dest.mergeCategoriesPartial(categoriesMap)
+			dest.mergeCategoriesPartial(categoriesMap, rec)
 		}
 	}
 	if len(source.CategoriesRemoved) > 0 {
+		if rec.emitting() {
+			for _, name := range source.CategoriesRemoved {
+				if _, exists := dest.Categories[name]; exists {
+					rec.add(CategoryRemoved{Name: name})
+				}
+			}
+		}
 		remove(source.CategoriesRemoved, &dest.Categories)
 	}
 
@@ -103,10 +135,18 @@ func (dest *MainData) UpdateWithRawData(rawData map[string]interface{}, source *
 	// Handle tags ONLY if present in raw JSON
 	if tagsRaw, exists := rawData["tags"]; exists {
 		if _, ok := tagsRaw.([]interface{}); ok {
+			rec.tagsAdded(dest.Tags, source.Tags)
 			mergeSlice(source.Tags, &dest.Tags)
 		}
 	}
 	if len(source.TagsRemoved) > 0 {
+		if rec.emitting() {
+			for _, tag := range source.TagsRemoved {
+				if slices.Contains(dest.Tags, tag) {
+					rec.add(TagRemoved{Tag: tag})
+				}
+			}
+		}
 		removeSlice(source.TagsRemoved, &dest.Tags)
 	}
 
@@ -119,7 +159,7 @@ func (dest *MainData) UpdateWithRawData(rawData map[string]interface{}, source *
 }
 
 // mergeTorrentsPartial merges only the fields that are present in the update
-func (dest *MainData) mergeTorrentsPartial(torrentsMap map[string]interface{}) {
+func (dest *MainData) mergeTorrentsPartial(torrentsMap map[string]interface{}, rec *syncEventRecorder) {
 	for hash, torrentRaw := range torrentsMap {
 		updateMap, ok := torrentRaw.(map[string]interface{})
 		if !ok {
@@ -132,15 +172,29 @@ func (dest *MainData) mergeTorrentsPartial(torrentsMap map[string]interface{}) {
 			existing = Torrent{Hash: hash}
 		}
 
+		prev := existing
+
 		// Always start with existing data and update only provided fields
 		updateTorrentFields(&existing, updateMap)
 
 		dest.Torrents[hash] = existing
+		rec.touch(hash)
+
+		if rec.emitting() {
+			if !exists {
+				rec.add(TorrentAdded{Torrent: existing})
+				continue
+			}
+			for _, field := range slices.Sorted(maps.Keys(updateMap)) {
+				rec.add(TorrentFieldChanged{Hash: hash, Field: field, Value: updateMap[field]})
+			}
+			rec.torrentChanged(prev, existing)
+		}
 	}
 }
 
 // mergeCategoriesPartial merges only the fields that are present in the update
-func (dest *MainData) mergeCategoriesPartial(categoriesMap map[string]interface{}) {
+func (dest *MainData) mergeCategoriesPartial(categoriesMap map[string]interface{}, rec *syncEventRecorder) {
 	for name, categoryRaw := range categoriesMap {
 		updateMap, ok := categoryRaw.(map[string]interface{})
 		if !ok {
@@ -156,5 +210,9 @@ func (dest *MainData) mergeCategoriesPartial(categoriesMap map[string]interface{
 		// Always start with existing data and update only provided fields
 		updateCategoryFields(&existing, updateMap)
 		dest.Categories[name] = existing
+
+		if !exists {
+			rec.add(CategoryAdded{Category: existing})
+		}
 	}
 }
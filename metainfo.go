@@ -0,0 +1,39 @@
+package qbittorrent
+
+import (
+	"strings"
+
+	"github.com/autobrr/go-qbittorrent/pkg/metainfo"
+)
+
+// expectedTorrentIds returns the hashes qBittorrent will report for each .torrent payload, one
+// per payload. Payloads that cannot be parsed locally get an empty string; qBittorrent validates
+// them itself.
+func expectedTorrentIds(payloads ...[]byte) []string {
+	ids := make([]string, len(payloads))
+	for i, payload := range payloads {
+		if m, err := metainfo.Parse(payload); err == nil {
+			ids[i] = m.InfoHashes().ID()
+		}
+	}
+	return ids
+}
+
+// expectedTorrentIdsFromFile is expectedTorrentIds for a file, which is read without being
+// kept in memory for the upload
+func expectedTorrentIdsFromFile(path string) []string {
+	m, err := metainfo.ParseFile(path)
+	if err != nil {
+		return []string{""}
+	}
+	return []string{m.InfoHashes().ID()}
+}
+
+// MatchesInfoHashes reports whether the torrent is the one identified by hashes, comparing
+// InfohashV1 and InfohashV2. Servers that report neither are matched on Hash instead.
+func (t Torrent) MatchesInfoHashes(hashes metainfo.InfoHashes) bool {
+	if t.InfohashV1 == "" && t.InfohashV2 == "" {
+		return t.Hash != "" && strings.EqualFold(t.Hash, hashes.ID())
+	}
+	return hashes.Matches(t.InfohashV1, t.InfohashV2)
+}
@@ -0,0 +1,94 @@
+package qbittorrent
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/autobrr/go-qbittorrent/pkg/metainfo"
+)
+
+// a v1 torrent created by qBittorrent and the hash it reported for it
+const (
+	infohashTestTorrent = "d10:created by18:qBittorrent v5.1.013:creation datei1747004328e4:infod5:filesld6:lengthi21e4:pathl12:untitled.txteee4:name8:untitled12:piece lengthi16384e6:pieces20:\xb5|\x901\xce\xa3\xdb @$\xce\xbd\xd3\xb0\x0e\xd3\xba\xc0\xcc\xbd7:privatei1eee"
+	infohashTestHash    = "ead9241e611e9712f28b20b151f1a3ecd4a6178a"
+)
+
+func TestClient_AddTorrentFromMemory_ExpectedTorrentIds(t *testing.T) {
+	for name, contentType := range map[string]string{
+		"legacy text response": "text/plain; charset=UTF-8",
+		"json response":        "application/json",
+	} {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, "/api/v2/torrents/add", r.URL.Path)
+				w.Header().Set("Content-Type", contentType)
+				if contentType == "application/json" {
+					_, _ = w.Write([]byte(`{"success_count":1,"added_torrent_ids":["` + infohashTestHash + `"]}`))
+					return
+				}
+				_, _ = w.Write([]byte("Ok."))
+			}))
+			t.Cleanup(server.Close)
+
+			client := NewClient(Config{Host: server.URL})
+
+			res, err := client.AddTorrentsFromMemory([][]byte{[]byte(infohashTestTorrent), []byte("not a torrent")}, map[string]string{})
+			require.NoError(t, err)
+			assert.Equal(t, []string{infohashTestHash, ""}, res.ExpectedTorrentIds, "ids line up with the payloads")
+		})
+	}
+}
+
+func TestClient_AddTorrentFromFile_ExpectedTorrentIds(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; charset=UTF-8")
+		_, _ = w.Write([]byte("Ok."))
+	}))
+	t.Cleanup(server.Close)
+
+	client := NewClient(Config{Host: server.URL})
+
+	dir := t.TempDir()
+	for content, want := range map[string]string{infohashTestTorrent: infohashTestHash, "not a torrent": ""} {
+		path := filepath.Join(dir, "a.torrent")
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+
+		res, err := client.AddTorrentFromFile(path, nil)
+		require.NoError(t, err)
+		assert.Equal(t, []string{want}, res.ExpectedTorrentIds)
+	}
+
+	_, err := client.AddTorrentFromFile(filepath.Join(dir, "missing.torrent"), nil)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestTorrent_MatchesInfoHashes(t *testing.T) {
+	hashes := metainfo.InfoHashes{V1: infohashTestHash}
+
+	assert.True(t, Torrent{Hash: infohashTestHash, InfohashV1: infohashTestHash}.MatchesInfoHashes(hashes))
+	assert.True(t, Torrent{Hash: infohashTestHash}.MatchesInfoHashes(hashes))
+	assert.False(t, Torrent{Hash: "other", InfohashV1: "other"}.MatchesInfoHashes(hashes))
+	assert.False(t, Torrent{}.MatchesInfoHashes(hashes))
+}
+
+func TestClient_AddTorrentFromUrl_InvalidMagnet(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, _ *http.Request) {
+		t.Fatal("invalid magnets should not be sent to qBittorrent")
+	}))
+	t.Cleanup(server.Close)
+
+	client := NewClient(Config{Host: server.URL})
+
+	_, err := client.AddTorrentFromUrl("magnet:?dn=missing-hash", map[string]string{})
+	assert.ErrorIs(t, err, metainfo.ErrInvalidMagnet)
+
+	_, err = client.AddTorrentsFromUrlsCtx(context.Background(), []string{"https://example.com/a.torrent", "magnet:?xt=urn:btih:abc"}, map[string]string{})
+	assert.ErrorIs(t, err, metainfo.ErrInvalidMagnet)
+}
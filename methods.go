@@ -4,7 +4,9 @@ import (
 	"context"
 	"encoding/json"
 	"io"
+	"log/slog"
 	"net/http"
+	"os"
 	"strconv"
 	"strings"
 	"time"
@@ -12,6 +14,7 @@ import (
 	"github.com/Masterminds/semver"
 
 	"github.com/autobrr/go-qbittorrent/errors"
+	"github.com/autobrr/go-qbittorrent/pkg/metainfo"
 )
 
 // Login https://github.com/qbittorrent/qBittorrent/wiki/WebUI-API-(qBittorrent-4.1)#authentication
@@ -70,6 +73,7 @@ func (c *Client) LoginCtx(ctx context.Context) error {
 	}
 
 	c.log.Printf("logged into client: %v", c.cfg.Host)
+	c.slogger().LogAttrs(ctx, slog.LevelInfo, "logged in", c.hostAttr())
 
 	return nil
 }
@@ -372,6 +376,8 @@ func (d *MonitoredFolderTarget) UnmarshalJSON(data []byte) error {
 type MonitoredFolders map[string]MonitoredFolderTarget
 
 // GetDirectoryContent lists folders inside a directory (for autocomplete).
+//
+// Deprecated: use GetDirectoryPaths or GetDirectoryMetadata which return typed results.
 func (c *Client) GetDirectoryContent(dirPath string, withMetadata bool) (any, error) {
 	return c.GetDirectoryContentCtx(context.Background(), dirPath, withMetadata)
 }
@@ -380,10 +386,63 @@ func (c *Client) GetDirectoryContent(dirPath string, withMetadata bool) (any, er
 // Requires qBittorrent 5.0 and WebAPI >= 2.11.2.
 // Note: withMetadata parameter is not yet released in qBittorrent (as of Dec 2025),
 // expected in the next version. When false, returns []string; when true, returns []PathMetadata.
+//
+// Deprecated: use GetDirectoryPathsCtx or GetDirectoryMetadataCtx which return typed results.
 func (c *Client) GetDirectoryContentCtx(ctx context.Context, dirPath string, withMetadata bool) (any, error) {
+	// an error returns an untyped nil, not a nil slice wrapped in the interface
+	if withMetadata {
+		metadata, err := c.GetDirectoryMetadataCtx(ctx, dirPath)
+		if err != nil {
+			return nil, err
+		}
+		return metadata, nil
+	}
+
+	paths, err := c.GetDirectoryPathsCtx(ctx, dirPath)
+	if err != nil {
+		return nil, err
+	}
+	return paths, nil
+}
+
+// GetDirectoryPaths lists the absolute paths of folders inside a directory.
+func (c *Client) GetDirectoryPaths(dirPath string) ([]string, error) {
+	return c.GetDirectoryPathsCtx(context.Background(), dirPath)
+}
+
+// GetDirectoryPathsCtx lists the absolute paths of folders inside a directory.
+// Requires qBittorrent 5.0 and WebAPI >= 2.11.2.
+func (c *Client) GetDirectoryPathsCtx(ctx context.Context, dirPath string) ([]string, error) {
+	var paths []string
+	if err := c.getDirectoryContentCtx(ctx, dirPath, false, &paths); err != nil {
+		return nil, err
+	}
+
+	return paths, nil
+}
+
+// GetDirectoryMetadata lists folders inside a directory together with their metadata.
+func (c *Client) GetDirectoryMetadata(dirPath string) ([]PathMetadata, error) {
+	return c.GetDirectoryMetadataCtx(context.Background(), dirPath)
+}
+
+// GetDirectoryMetadataCtx lists folders inside a directory together with their metadata.
+// Requires qBittorrent 5.0 and WebAPI >= 2.11.2.
+// Note: withMetadata parameter is not yet released in qBittorrent (as of Dec 2025),
+// expected in the next version.
+func (c *Client) GetDirectoryMetadataCtx(ctx context.Context, dirPath string) ([]PathMetadata, error) {
+	var entries []PathMetadata
+	if err := c.getDirectoryContentCtx(ctx, dirPath, true, &entries); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+func (c *Client) getDirectoryContentCtx(ctx context.Context, dirPath string, withMetadata bool, result any) error {
 	minVersion, _ := semver.NewVersion("2.11.2")
 	if _, err := c.RequiresMinVersion(minVersion); err != nil {
-		return nil, err
+		return err
 	}
 
 	opts := map[string]string{
@@ -393,32 +452,32 @@ func (c *Client) GetDirectoryContentCtx(ctx context.Context, dirPath string, wit
 	}
 	resp, err := c.getCtx(ctx, "app/getDirectoryContent", opts)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not get directory content")
+		return errors.Wrap(err, "could not get directory content")
 	}
 	defer drainAndClose(resp)
 
+	/*
+		HTTP Status Code 	Scenario
+		400 	Directory path is empty or not absolute
+		404 	Directory does not exist
+		200 	All other scenarios
+	*/
 	switch resp.StatusCode {
 	case http.StatusOK:
 		break
+	case http.StatusBadRequest:
+		return errors.Wrap(ErrInvalidDirectoryPath, "dirPath: %s", dirPath)
+	case http.StatusNotFound:
+		return errors.Wrap(ErrDirectoryNotFound, "dirPath: %s", dirPath)
 	default:
-		return nil, errors.Wrap(ErrUnexpectedStatus, "could not get directory content; status code: %d", resp.StatusCode)
+		return errors.Wrap(ErrUnexpectedStatus, "could not get directory content; status code: %d", resp.StatusCode)
 	}
 
-	decoder := json.NewDecoder(resp.Body)
-
-	if withMetadata {
-		var result []PathMetadata
-		if err := decoder.Decode(&result); err != nil {
-			return nil, errors.Wrap(err, "could not unmarshal body")
-		}
-		return result, nil
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return errors.Wrap(err, "could not unmarshal body")
 	}
 
-	var paths []string
-	if err := decoder.Decode(&paths); err != nil {
-		return nil, errors.Wrap(err, "could not unmarshal body")
-	}
-	return paths, nil
+	return nil
 }
 
 // GetDefaultSavePath get default save path.
@@ -655,6 +714,7 @@ func (c *Client) AddTorrentFromMemoryCtx(ctx context.Context, buf []byte, option
 			if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
 				return nil, errors.Wrap(err, "could not unmarshal body")
 			}
+			res.ExpectedTorrentIds = expectedTorrentIds(buf)
 			return &res, nil
 		}
 
@@ -671,7 +731,7 @@ func (c *Client) AddTorrentFromMemoryCtx(ctx context.Context, buf []byte, option
 		return nil, errors.Wrap(ErrUnexpectedContentType, "could not add torrent | unexpected content-type: %s", resp.Header.Get("Content-Type"))
 	}
 
-	res := TorrentAddResponse{SuccessCount: 1}
+	res := TorrentAddResponse{SuccessCount: 1, ExpectedTorrentIds: expectedTorrentIds(buf)}
 
 	return &res, nil
 }
@@ -684,44 +744,19 @@ func (c *Client) AddTorrentsFromMemory(files [][]byte, options map[string]string
 
 // AddTorrentsFromMemoryCtx adds multiple torrents from memory in a single request.
 // qBittorrent's API accepts multiple "torrents" form fields, allowing batch uploads.
+// Batches larger than Config.MaxRequestSize are split over several requests and their
+// responses merged. If one fails, the responses so far are returned along with the error.
 func (c *Client) AddTorrentsFromMemoryCtx(ctx context.Context, files [][]byte, options map[string]string) (*TorrentAddResponse, error) {
 	if len(files) == 0 {
 		return nil, ErrEmptyInput
 	}
 
-	resp, err := c.postMultiMemoryCtx(ctx, "torrents/add", files, options)
-	if err != nil {
-		return nil, errors.Wrap(err, "could not add torrents")
-	}
-
-	defer drainAndClose(resp)
-
-	switch resp.StatusCode {
-	case http.StatusOK, http.StatusAccepted, http.StatusNoContent:
-		if strings.HasPrefix(resp.Header.Get("Content-Type"), "application/json") {
-			var res TorrentAddResponse
-			if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
-				return nil, errors.Wrap(err, "could not unmarshal body")
-			}
-			return &res, nil
-		}
-
-		break
-	case http.StatusConflict:
-		return nil, errors.Wrap(ErrTorrentAddFailed, "could not add torrents | conflicts detected")
-	case http.StatusUnsupportedMediaType:
-		return nil, errors.Wrap(ErrTorrentAddFailed, "could not add torrents | torrent file not valid")
-	default:
-		return nil, errors.Wrap(ErrUnexpectedStatus, "could not add torrents | unexpected status code: %d", resp.StatusCode)
-	}
-
-	if !strings.HasPrefix(resp.Header.Get("Content-Type"), "text/plain") {
-		return nil, errors.Wrap(ErrUnexpectedContentType, "could not add torrents | unexpected content-type: %s", resp.Header.Get("Content-Type"))
+	uploads := make([]uploadFile, 0, len(files))
+	for _, file := range files {
+		uploads = append(uploads, memoryUploadFile(file))
 	}
 
-	res := TorrentAddResponse{SuccessCount: 1}
-
-	return &res, nil
+	return c.uploadTorrentsCtx(ctx, uploads, options, nil)
 }
 
 // AddTorrentFromFile add new torrent from torrent file
@@ -730,7 +765,12 @@ func (c *Client) AddTorrentFromFile(filePath string, options map[string]string)
 }
 
 func (c *Client) AddTorrentFromFileCtx(ctx context.Context, filePath string, options map[string]string) (*TorrentAddResponse, error) {
-	resp, err := c.postFileCtx(ctx, "torrents/add", filePath, options)
+	buf, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not add torrent; error opening file %v", filePath)
+	}
+
+	resp, err := c.postMemoryCtx(ctx, "torrents/add", buf, options)
 	if err != nil {
 		return nil, errors.Wrap(err, "could not add torrent; filePath: %s", filePath)
 	}
@@ -744,6 +784,7 @@ func (c *Client) AddTorrentFromFileCtx(ctx context.Context, filePath string, opt
 			if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
 				return nil, errors.Wrap(err, "could not unmarshal body")
 			}
+			res.ExpectedTorrentIds = expectedTorrentIds(buf)
 			return &res, nil
 		}
 
@@ -760,7 +801,7 @@ func (c *Client) AddTorrentFromFileCtx(ctx context.Context, filePath string, opt
 		return nil, errors.Wrap(ErrUnexpectedContentType, "could not add torrent: file: %s | unexpected content-type: %s", filePath, resp.Header.Get("Content-Type"))
 	}
 
-	res := TorrentAddResponse{SuccessCount: 1}
+	res := TorrentAddResponse{SuccessCount: 1, ExpectedTorrentIds: expectedTorrentIds(buf)}
 
 	return &res, nil
 }
@@ -775,6 +816,12 @@ func (c *Client) AddTorrentFromUrlCtx(ctx context.Context, url string, options m
 		return nil, ErrNoTorrentURLProvided
 	}
 
+	if metainfo.IsMagnetURI(url) {
+		if _, err := metainfo.ParseMagnet(url); err != nil {
+			return nil, errors.Wrap(err, "could not add torrent; url: %v", url)
+		}
+	}
+
 	options["urls"] = url
 
 	resp, err := c.postCtx(ctx, "torrents/add", options)
@@ -818,6 +865,14 @@ func (c *Client) AddTorrentsFromUrlsCtx(ctx context.Context, urls []string, opti
 		return nil, ErrNoTorrentURLProvided
 	}
 
+	for _, url := range urls {
+		if metainfo.IsMagnetURI(url) {
+			if _, err := metainfo.ParseMagnet(url); err != nil {
+				return nil, errors.Wrap(err, "could not add torrents; url: %v", url)
+			}
+		}
+	}
+
 	options["urls"] = strings.Join(urls, "\n")
 
 	resp, err := c.postCtx(ctx, "torrents/add", options)
@@ -1244,6 +1299,67 @@ func (c *Client) SetLocationCtx(ctx context.Context, hashes []string, location s
 	}
 }
 
+// SetSavePath set the save path of torrents without relocating data of torrents that are still incomplete
+func (c *Client) SetSavePath(hashes []string, path string) error {
+	return c.SetSavePathCtx(context.Background(), hashes, path)
+}
+
+// SetSavePathCtx set the save path of torrents without relocating data of torrents that are still incomplete
+// Requires qBittorrent v4.4.0+ (WebAPI v2.8.4+)
+func (c *Client) SetSavePathCtx(ctx context.Context, hashes []string, path string) error {
+	return c.setTorrentPathCtx(ctx, "torrents/setSavePath", "save path", hashes, path)
+}
+
+// SetDownloadPath set the download path used by incomplete torrents
+func (c *Client) SetDownloadPath(hashes []string, path string) error {
+	return c.SetDownloadPathCtx(context.Background(), hashes, path)
+}
+
+// SetDownloadPathCtx set the download path used by incomplete torrents
+// Requires qBittorrent v4.4.0+ (WebAPI v2.8.4+)
+func (c *Client) SetDownloadPathCtx(ctx context.Context, hashes []string, path string) error {
+	return c.setTorrentPathCtx(ctx, "torrents/setDownloadPath", "download path", hashes, path)
+}
+
+func (c *Client) setTorrentPathCtx(ctx context.Context, endpoint string, name string, hashes []string, path string) error {
+	minVersion, _ := semver.NewVersion("2.8.4")
+	if _, err := c.RequiresMinVersion(minVersion); err != nil {
+		return err
+	}
+
+	opts := map[string]string{
+		"id":   strings.Join(hashes, "|"),
+		"path": path,
+	}
+
+	resp, err := c.postCtx(ctx, endpoint, opts)
+	if err != nil {
+		return errors.Wrap(err, "could not set %s; hashes: %v | path: %s", name, hashes, path)
+	}
+
+	defer drainAndClose(resp)
+
+	/*
+		HTTP Status Code 	Scenario
+		400 	Path is empty or not absolute
+		403 	User does not have write access to directory
+		409 	Unable to create directory
+		200 	All other scenarios
+	*/
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusNoContent:
+		return nil
+	case http.StatusBadRequest:
+		return errors.Wrap(ErrInvalidTorrentPath, "%s: %s", name, path)
+	case http.StatusForbidden:
+		return errors.Wrap(ErrTorrentPathForbidden, "%s: %s", name, path)
+	case http.StatusConflict:
+		return errors.Wrap(ErrTorrentPathConflict, "%s: %s", name, path)
+	default:
+		return errors.Wrap(ErrUnexpectedStatus, "could not set %s; hashes: %v | path: %v | status code: %d", name, hashes, path, resp.StatusCode)
+	}
+}
+
 func (c *Client) CreateCategory(category string, path string) error {
 	return c.CreateCategoryCtx(context.Background(), category, path)
 }
@@ -2952,6 +3068,7 @@ func (c *Client) ReannounceTorrentWithRetry(ctx context.Context, hash string, op
 
 	for attempts < maxAttempts {
 		c.log.Printf("re-announce %s attempt: %d", hash, attempts)
+		c.slogger().LogAttrs(ctx, slog.LevelDebug, "checking trackers for re-announce", slog.String("hash", hash), slog.Int("attempt", attempts))
 
 		// add delay for next run
 		time.Sleep(time.Duration(interval) * time.Second)
@@ -2971,6 +3088,7 @@ func (c *Client) ReannounceTorrentWithRetry(ctx context.Context, hash string, op
 		// check if status not working or something else
 		if isTrackerStatusOK(trackers) {
 			c.log.Printf("re-announce for %v OK", hash)
+			c.slogger().LogAttrs(ctx, slog.LevelInfo, "re-announce succeeded", slog.String("hash", hash), slog.Int("attempt", attempts))
 
 			// if working lets return
 			return nil
@@ -2988,6 +3106,7 @@ func (c *Client) ReannounceTorrentWithRetry(ctx context.Context, hash string, op
 	// delete on failure to reannounce
 	if deleteOnFailure {
 		c.log.Printf("re-announce for %s took too long, deleting torrent", hash)
+		c.slogger().LogAttrs(ctx, slog.LevelWarn, "re-announce took too long, deleting torrent", slog.String("hash", hash), slog.Int("attempt", attempts))
 
 		if err := c.DeleteTorrentsCtx(ctx, []string{hash}, false); err != nil {
 			return errors.Wrap(err, "could not delete torrent with hash: %s", hash)
@@ -3032,6 +3151,147 @@ func (c *Client) GetTorrentsWebSeedsCtx(ctx context.Context, hash string) ([]Web
 	return m, nil
 }
 
+// AddTorrentWebSeeds add web seeds (url seeds) to a torrent
+func (c *Client) AddTorrentWebSeeds(hash string, urls []string) error {
+	return c.AddTorrentWebSeedsCtx(context.Background(), hash, urls)
+}
+
+// AddTorrentWebSeedsCtx add web seeds (url seeds) to a torrent
+// Requires qBittorrent v5.1.0+ (WebAPI v2.11.3+)
+func (c *Client) AddTorrentWebSeedsCtx(ctx context.Context, hash string, urls []string) error {
+	minVersion, _ := semver.NewVersion("2.11.3")
+	if _, err := c.RequiresMinVersion(minVersion); err != nil {
+		return err
+	}
+
+	if len(urls) == 0 {
+		return ErrEmptyInput
+	}
+
+	opts := map[string]string{
+		"hash": hash,
+		"urls": strings.Join(urls, "|"),
+	}
+
+	resp, err := c.postCtx(ctx, "torrents/addWebSeeds", opts)
+	if err != nil {
+		return errors.Wrap(err, "could not add webseeds; hash: %s | urls: %v", hash, urls)
+	}
+
+	defer drainAndClose(resp)
+
+	/*
+		HTTP Status Code 	Scenario
+		400 	At least one url is not a valid URL
+		404 	Torrent hash was not found
+		200 	All other scenarios
+	*/
+	switch resp.StatusCode {
+	case http.StatusBadRequest:
+		return errors.Wrap(ErrInvalidURL, "urls: %v", urls)
+	case http.StatusNotFound:
+		return errors.Wrap(ErrTorrentNotFound, "torrent hash: %v", hash)
+	case http.StatusOK, http.StatusNoContent:
+		return nil
+	default:
+		return errors.Wrap(ErrUnexpectedStatus, "could not add webseeds; hash: %s | urls: %v | status code: %d", hash, urls, resp.StatusCode)
+	}
+}
+
+// EditTorrentWebSeed replace a web seed of a torrent
+func (c *Client) EditTorrentWebSeed(hash string, origURL, newURL string) error {
+	return c.EditTorrentWebSeedCtx(context.Background(), hash, origURL, newURL)
+}
+
+// EditTorrentWebSeedCtx replace a web seed of a torrent
+// Requires qBittorrent v5.1.0+ (WebAPI v2.11.3+)
+func (c *Client) EditTorrentWebSeedCtx(ctx context.Context, hash string, origURL, newURL string) error {
+	minVersion, _ := semver.NewVersion("2.11.3")
+	if _, err := c.RequiresMinVersion(minVersion); err != nil {
+		return err
+	}
+
+	opts := map[string]string{
+		"hash":    hash,
+		"origUrl": origURL,
+		"newUrl":  newURL,
+	}
+
+	resp, err := c.postCtx(ctx, "torrents/editWebSeed", opts)
+	if err != nil {
+		return errors.Wrap(err, "could not edit webseed; hash: %s | old: %s | new: %s", hash, origURL, newURL)
+	}
+
+	defer drainAndClose(resp)
+
+	/*
+		HTTP Status Code 	Scenario
+		400 	origUrl or newUrl is not a valid URL
+		404 	Torrent hash was not found
+		409 	origUrl was not found
+		200 	All other scenarios
+	*/
+	switch resp.StatusCode {
+	case http.StatusBadRequest:
+		return errors.Wrap(ErrInvalidURL, "old url: %v | new url: %v", origURL, newURL)
+	case http.StatusNotFound:
+		return errors.Wrap(ErrTorrentNotFound, "torrent hash: %v", hash)
+	case http.StatusConflict:
+		return errors.Wrap(ErrWebSeedNotFound, "url: %v", origURL)
+	case http.StatusOK, http.StatusNoContent:
+		return nil
+	default:
+		return errors.Wrap(ErrUnexpectedStatus, "could not edit webseed; hash: %s | old: %s | new: %s | status code: %d", hash, origURL, newURL, resp.StatusCode)
+	}
+}
+
+// RemoveTorrentWebSeeds remove web seeds from a torrent
+func (c *Client) RemoveTorrentWebSeeds(hash string, urls []string) error {
+	return c.RemoveTorrentWebSeedsCtx(context.Background(), hash, urls)
+}
+
+// RemoveTorrentWebSeedsCtx remove web seeds from a torrent
+// Requires qBittorrent v5.1.0+ (WebAPI v2.11.3+)
+func (c *Client) RemoveTorrentWebSeedsCtx(ctx context.Context, hash string, urls []string) error {
+	minVersion, _ := semver.NewVersion("2.11.3")
+	if _, err := c.RequiresMinVersion(minVersion); err != nil {
+		return err
+	}
+
+	if len(urls) == 0 {
+		return ErrEmptyInput
+	}
+
+	opts := map[string]string{
+		"hash": hash,
+		"urls": strings.Join(urls, "|"),
+	}
+
+	resp, err := c.postCtx(ctx, "torrents/removeWebSeeds", opts)
+	if err != nil {
+		return errors.Wrap(err, "could not remove webseeds; hash: %s | urls: %v", hash, urls)
+	}
+
+	defer drainAndClose(resp)
+
+	/*
+		HTTP Status Code 	Scenario
+		400 	At least one url is not a valid URL
+		404 	Torrent hash was not found
+		200 	All other scenarios
+	*/
+	switch resp.StatusCode {
+	case http.StatusBadRequest:
+		return errors.Wrap(ErrInvalidURL, "urls: %v", urls)
+	case http.StatusNotFound:
+		return errors.Wrap(ErrTorrentNotFound, "torrent hash: %v", hash)
+	case http.StatusOK, http.StatusNoContent:
+		return nil
+	default:
+		return errors.Wrap(ErrUnexpectedStatus, "could not remove webseeds; hash: %s | urls: %v | status code: %d", hash, urls, resp.StatusCode)
+	}
+}
+
 // GetTorrentPeers retrieves the list of peers for a torrent
 func (c *Client) GetTorrentPeers(hash string, rid int64) (*TorrentPeersResponse, error) {
 	return c.GetTorrentPeersCtx(context.Background(), hash, rid)
@@ -0,0 +1,238 @@
+package qbittorrent
+
+import (
+	"cmp"
+	"context"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/autobrr/go-qbittorrent/pkg/promtext"
+)
+
+// Metrics collects client request and sync metrics and serves them in the Prometheus text format.
+// Set it as Config.Metrics, or add Middleware to a client, and mount it on a metrics endpoint.
+// One Metrics may be shared by several clients; the torrent counts then follow the latest sync.
+// A nil *Metrics ignores every observation.
+type Metrics struct {
+	mu sync.Mutex
+
+	requests        map[requestMetricKey]uint64
+	requestDuration map[string]*histogram
+	retries         map[string]uint64
+	relogins        uint64
+
+	syncDuration    histogram
+	fullSyncs       uint64
+	partialSyncs    uint64
+	syncErrors      uint64
+	ridJumps        uint64
+	lastSuccessSync time.Time
+	torrentStates   map[TorrentState]int
+}
+
+type requestMetricKey struct {
+	endpoint string
+	code     string
+}
+
+type histogram struct {
+	counts []uint64
+	sum    float64
+}
+
+func (h *histogram) observe(seconds float64) {
+	if h.counts == nil {
+		h.counts = make([]uint64, len(promtext.DefaultBuckets)+1)
+	}
+	h.counts[promtext.BucketIndex(promtext.DefaultBuckets, seconds)]++
+	h.sum += seconds
+}
+
+func (h *histogram) write(w *promtext.Writer, name string, labels []promtext.Label) {
+	counts := h.counts
+	if counts == nil {
+		counts = make([]uint64, len(promtext.DefaultBuckets)+1)
+	}
+	w.Histogram(name, labels, promtext.DefaultBuckets, counts, h.sum)
+}
+
+func NewMetrics() *Metrics {
+	return &Metrics{
+		requests:        make(map[requestMetricKey]uint64),
+		requestDuration: make(map[string]*histogram),
+		retries:         make(map[string]uint64),
+		torrentStates:   make(map[TorrentState]int),
+	}
+}
+
+// Middleware counts and times every request by endpoint and status code.
+// Config.Metrics installs it automatically.
+func (m *Metrics) Middleware() Middleware {
+	return func(next RequestHandler) RequestHandler {
+		return func(ctx context.Context, req *Request) (*http.Response, error) {
+			start := time.Now()
+			resp, err := next(ctx, req)
+
+			code := "error"
+			if err == nil {
+				code = strconv.Itoa(resp.StatusCode)
+			}
+			m.observeRequest(req.Endpoint, code, time.Since(start))
+
+			return resp, err
+		}
+	}
+}
+
+func (m *Metrics) observeRequest(endpoint, code string, duration time.Duration) {
+	if m == nil {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.requests[requestMetricKey{endpoint: endpoint, code: code}]++
+	h, ok := m.requestDuration[endpoint]
+	if !ok {
+		h = &histogram{}
+		m.requestDuration[endpoint] = h
+	}
+	h.observe(duration.Seconds())
+}
+
+func (m *Metrics) observeRetry(endpoint string) {
+	if m == nil {
+		return
+	}
+
+	m.mu.Lock()
+	m.retries[endpoint]++
+	m.mu.Unlock()
+}
+
+func (m *Metrics) observeRelogin() {
+	if m == nil {
+		return
+	}
+
+	m.mu.Lock()
+	m.relogins++
+	m.mu.Unlock()
+}
+
+// syncObservation is the outcome of one SyncManager sync
+type syncObservation struct {
+	duration time.Duration
+	err      error
+	full     bool
+	ridJump  bool
+	states   map[TorrentState]int
+}
+
+func (m *Metrics) observeSync(o syncObservation) {
+	if m == nil {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.syncDuration.observe(o.duration.Seconds())
+	if o.err != nil {
+		m.syncErrors++
+		return
+	}
+
+	if o.full {
+		m.fullSyncs++
+	} else {
+		m.partialSyncs++
+	}
+	if o.ridJump {
+		m.ridJumps++
+	}
+	m.lastSuccessSync = time.Now()
+	m.torrentStates = o.states
+}
+
+// ServeHTTP renders the metrics in the Prometheus text exposition format
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", promtext.ContentType)
+
+	out := promtext.NewWriter(w)
+	m.write(out)
+	_ = out.Flush() // the client went away
+}
+
+func (m *Metrics) write(w *promtext.Writer) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	w.Family("qbittorrent_client_requests_total", "WebAPI requests by endpoint and HTTP status code, or error if none was received.", "counter")
+	keys := slices.SortedFunc(maps.Keys(m.requests), func(a, b requestMetricKey) int {
+		return cmp.Or(cmp.Compare(a.endpoint, b.endpoint), cmp.Compare(a.code, b.code))
+	})
+	for _, key := range keys {
+		w.Sample("qbittorrent_client_requests_total", []promtext.Label{{Name: "endpoint", Value: key.endpoint}, {Name: "code", Value: key.code}}, float64(m.requests[key]))
+	}
+
+	w.Family("qbittorrent_client_request_duration_seconds", "WebAPI request latency by endpoint, including retries.", "histogram")
+	for _, endpoint := range slices.Sorted(maps.Keys(m.requestDuration)) {
+		m.requestDuration[endpoint].write(w, "qbittorrent_client_request_duration_seconds", []promtext.Label{{Name: "endpoint", Value: endpoint}})
+	}
+
+	w.Family("qbittorrent_client_retries_total", "WebAPI request retries by endpoint.", "counter")
+	for _, endpoint := range slices.Sorted(maps.Keys(m.retries)) {
+		w.Sample("qbittorrent_client_retries_total", []promtext.Label{{Name: "endpoint", Value: endpoint}}, float64(m.retries[endpoint]))
+	}
+
+	w.Family("qbittorrent_client_relogins_total", "Logins made after the session expired.", "counter")
+	w.Sample("qbittorrent_client_relogins_total", nil, float64(m.relogins))
+
+	w.Family("qbittorrent_sync_duration_seconds", "Duration of sync/maindata updates.", "histogram")
+	m.syncDuration.write(w, "qbittorrent_sync_duration_seconds", nil)
+
+	w.Family("qbittorrent_sync_updates_total", "Successful sync/maindata updates by type.", "counter")
+	w.Sample("qbittorrent_sync_updates_total", []promtext.Label{{Name: "type", Value: "full"}}, float64(m.fullSyncs))
+	w.Sample("qbittorrent_sync_updates_total", []promtext.Label{{Name: "type", Value: "partial"}}, float64(m.partialSyncs))
+
+	w.Family("qbittorrent_sync_errors_total", "Failed sync/maindata updates.", "counter")
+	w.Sample("qbittorrent_sync_errors_total", nil, float64(m.syncErrors))
+
+	w.Family("qbittorrent_sync_rid_jumps_total", "Updates whose response id did not follow the previous one, such as after a server restart.", "counter")
+	w.Sample("qbittorrent_sync_rid_jumps_total", nil, float64(m.ridJumps))
+
+	w.Family("qbittorrent_sync_last_success_timestamp_seconds", "Unix time of the last successful sync/maindata update.", "gauge")
+	if !m.lastSuccessSync.IsZero() {
+		w.Sample("qbittorrent_sync_last_success_timestamp_seconds", nil, float64(m.lastSuccessSync.UnixNano())/1e9)
+	}
+
+	w.Family("qbittorrent_sync_torrents", "Torrents by state as of the last successful sync.", "gauge")
+	for _, state := range slices.Sorted(maps.Keys(m.torrentStates)) {
+		w.Sample("qbittorrent_sync_torrents", []promtext.Label{{Name: "state", Value: string(state)}}, float64(m.torrentStates[state]))
+	}
+}
+
+// observeSync records the outcome of a sync in the client's metrics. It is called with sm.mu held.
+func (sm *SyncManager) observeSync(err error, prevRid int64, rec *syncEventRecorder) {
+	if sm.client == nil || sm.client.cfg.Metrics == nil {
+		return
+	}
+
+	o := syncObservation{duration: sm.lastSyncDuration, err: err}
+	if err == nil {
+		o.full = rec.fullUpdate
+		o.ridJump = prevRid != 0 && sm.rid != prevRid+1
+		o.states = make(map[TorrentState]int)
+		for _, torrent := range sm.data.Torrents {
+			o.states[torrent.State]++
+		}
+	}
+
+	sm.client.cfg.Metrics.observeSync(o)
+}
@@ -0,0 +1,51 @@
+package qbittorrent
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/autobrr/go-qbittorrent/pkg/promtext"
+)
+
+func TestMetrics(t *testing.T) {
+	metrics := NewMetrics()
+	sm := newSyncManagerWithBodies(
+		`{"rid":1,"full_update":true,"torrents":{"aaa":{"state":"downloading"},"bbb":{"state":"uploading"}},"server_state":{}}`,
+		`{"rid":2,"torrents":{"bbb":{"state":"downloading"}}}`,
+		`{"rid":7,"torrents_removed":["aaa"]}`,
+	)
+	sm.client.cfg.Metrics = metrics
+	sm.client.Use(metrics.Middleware())
+
+	for range 3 {
+		require.NoError(t, sm.Sync(context.Background()))
+	}
+
+	rec := httptest.NewRecorder()
+	metrics.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Equal(t, promtext.ContentType, rec.Header().Get("Content-Type"))
+
+	body := rec.Body.String()
+	for _, line := range []string{
+		"# TYPE qbittorrent_client_requests_total counter\n",
+		`qbittorrent_client_requests_total{endpoint="sync/maindata",code="200"} 3` + "\n",
+		`qbittorrent_client_request_duration_seconds_count{endpoint="sync/maindata"} 3` + "\n",
+		`qbittorrent_client_request_duration_seconds_bucket{endpoint="sync/maindata",le="+Inf"} 3` + "\n",
+		"qbittorrent_client_relogins_total 0\n",
+		"qbittorrent_sync_duration_seconds_count 3\n",
+		`qbittorrent_sync_updates_total{type="full"} 1` + "\n",
+		`qbittorrent_sync_updates_total{type="partial"} 2` + "\n",
+		"qbittorrent_sync_rid_jumps_total 1\n",
+		"qbittorrent_sync_errors_total 0\n",
+		`qbittorrent_sync_torrents{state="downloading"} 1` + "\n",
+	} {
+		assert.Contains(t, body, line)
+	}
+	assert.NotContains(t, body, `state="uploading"`)
+	assert.Contains(t, body, "qbittorrent_sync_last_success_timestamp_seconds ")
+}
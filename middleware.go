@@ -0,0 +1,72 @@
+package qbittorrent
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+// Request is a call to the WebAPI as seen by middleware
+type Request struct {
+	// Endpoint is the WebAPI method, relative to /api/v2/, e.g. "torrents/info"
+	Endpoint string
+	Method   string
+	// Params are the query or form parameters the request was built from, including the
+	// credentials of auth/login. They are shared with the caller and must not be modified;
+	// HTTP already carries them.
+	Params map[string]string
+	// HTTP is the request that will be sent. Middleware may add headers to it.
+	HTTP *http.Request
+}
+
+// RequestHandler sends a request, including any retries and re-logins, and returns its response
+type RequestHandler func(ctx context.Context, req *Request) (*http.Response, error)
+
+// Middleware wraps every request made by the client. It can inspect or change the request,
+// return early without calling next, or observe the response status and duration:
+//
+//	client.Use(func(next qbittorrent.RequestHandler) qbittorrent.RequestHandler {
+//		return func(ctx context.Context, req *qbittorrent.Request) (*http.Response, error) {
+//			start := time.Now()
+//			resp, err := next(ctx, req)
+//			if err == nil {
+//				log.Printf("%s %d %s", req.Endpoint, resp.StatusCode, time.Since(start))
+//			}
+//			return resp, err
+//		}
+//	})
+type Middleware func(next RequestHandler) RequestHandler
+
+// Use appends middleware to the chain that wraps every request. The first middleware added is
+// the outermost one. Use is safe to call concurrently with requests, which pick up the chain
+// as it was when they started.
+func (c *Client) Use(middleware ...Middleware) *Client {
+	c.middlewareMu.Lock()
+	defer c.middlewareMu.Unlock()
+
+	// never append in place, requests in flight may still hold the old slice
+	c.middleware = append(c.middleware[:len(c.middleware):len(c.middleware)], middleware...)
+	return c
+}
+
+// send runs req through the middleware chain, ending with do, and logs the outcome
+func (c *Client) send(ctx context.Context, req *Request, do RequestHandler) (*http.Response, error) {
+	// a few methods pass endpoints with a leading slash
+	req.Endpoint = strings.TrimPrefix(req.Endpoint, "/")
+
+	c.middlewareMu.RLock()
+	chain := c.middleware
+	c.middlewareMu.RUnlock()
+
+	handler := do
+	for i := len(chain) - 1; i >= 0; i-- {
+		handler = chain[i](handler)
+	}
+
+	return c.logRequest(ctx, req, handler)
+}
+
+// retryHandler sends the request with retries and re-logins
+func (c *Client) retryHandler(ctx context.Context, req *Request) (*http.Response, error) {
+	return c.retryDo(ctx, req)
+}
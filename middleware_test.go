@@ -0,0 +1,75 @@
+package qbittorrent
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_Use(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "audit", r.Header.Get("X-Request-Source"))
+		_, _ = w.Write([]byte("v5.0.0"))
+	}))
+	t.Cleanup(server.Close)
+
+	var calls []string
+	var seen []*Request
+	var statuses []int
+	client := NewClient(Config{Host: server.URL, APIKey: "key"}).Use(
+		func(next RequestHandler) RequestHandler {
+			return func(ctx context.Context, req *Request) (*http.Response, error) {
+				calls = append(calls, "outer")
+				resp, err := next(ctx, req)
+				if err == nil {
+					statuses = append(statuses, resp.StatusCode)
+				}
+				return resp, err
+			}
+		},
+		func(next RequestHandler) RequestHandler {
+			return func(ctx context.Context, req *Request) (*http.Response, error) {
+				calls = append(calls, "inner")
+				seen = append(seen, req)
+				req.HTTP.Header.Set("X-Request-Source", "audit")
+				return next(ctx, req)
+			}
+		},
+	)
+
+	version, err := client.GetAppVersion()
+	require.NoError(t, err)
+	assert.Equal(t, "v5.0.0", version)
+
+	_, err = client.GetTorrentPieceStates("abc")
+	require.Error(t, err, "the fake server does not return JSON")
+
+	assert.Equal(t, []string{"outer", "inner", "outer", "inner"}, calls)
+	assert.Equal(t, []int{http.StatusOK, http.StatusOK}, statuses)
+	require.Len(t, seen, 2)
+	assert.Equal(t, "app/version", seen[0].Endpoint)
+	assert.Equal(t, http.MethodGet, seen[0].Method)
+	assert.Equal(t, "torrents/pieceStates", seen[1].Endpoint)
+	assert.Equal(t, map[string]string{"hash": "abc"}, seen[1].Params)
+}
+
+func TestClient_Use_ShortCircuit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request to %s", r.URL.Path)
+	}))
+	t.Cleanup(server.Close)
+
+	injected := assert.AnError
+	client := NewClient(Config{Host: server.URL, APIKey: "key"}).Use(func(next RequestHandler) RequestHandler {
+		return func(ctx context.Context, req *Request) (*http.Response, error) {
+			return nil, injected
+		}
+	})
+
+	_, err := client.GetAppVersion()
+	assert.ErrorIs(t, err, injected)
+}
@@ -0,0 +1,269 @@
+package metainfo
+
+import (
+	"bytes"
+	"slices"
+	"strconv"
+
+	"github.com/autobrr/go-qbittorrent/errors"
+)
+
+// maxDepth bounds list and dictionary nesting so hostile input cannot exhaust the stack
+const maxDepth = 256
+
+var (
+	ErrInvalidBencode  = errors.New("invalid bencode")
+	ErrInvalidMetaInfo = errors.New("invalid torrent metainfo")
+	ErrInvalidMagnet   = errors.New("invalid magnet link")
+)
+
+// Decode decodes a single bencoded value. Integers decode to int64, byte strings to string,
+// lists to []any and dictionaries to map[string]any.
+//
+// Decoding is strict: integers must not have leading zeros or be negative zero, dictionary keys
+// must be unique and sorted, and no data may follow the value. Errors wrap ErrInvalidBencode.
+func Decode(data []byte) (any, error) {
+	d := &decoder{data: data}
+
+	value, err := d.value(0)
+	if err != nil {
+		return nil, err
+	}
+
+	if d.pos != len(d.data) {
+		return nil, d.errorf("trailing data")
+	}
+
+	return value, nil
+}
+
+// Encode bencodes v, which may contain integers, strings, byte slices, []any, []string and
+// map[string]any values. Dictionary keys are written in sorted order.
+func Encode(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := encode(&buf, v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func encode(buf *bytes.Buffer, v any) error {
+	switch v := v.(type) {
+	case int:
+		writeInt(buf, int64(v))
+	case int64:
+		writeInt(buf, v)
+	case string:
+		writeString(buf, v)
+	case []byte:
+		writeString(buf, string(v))
+	case []string:
+		buf.WriteByte('l')
+		for _, s := range v {
+			writeString(buf, s)
+		}
+		buf.WriteByte('e')
+	case []any:
+		buf.WriteByte('l')
+		for _, item := range v {
+			if err := encode(buf, item); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('e')
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		slices.Sort(keys)
+
+		buf.WriteByte('d')
+		for _, key := range keys {
+			writeString(buf, key)
+			if err := encode(buf, v[key]); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('e')
+	default:
+		return errors.Wrap(ErrInvalidBencode, "cannot encode %T", v)
+	}
+
+	return nil
+}
+
+func writeInt(buf *bytes.Buffer, v int64) {
+	buf.WriteByte('i')
+	buf.WriteString(strconv.FormatInt(v, 10))
+	buf.WriteByte('e')
+}
+
+func writeString(buf *bytes.Buffer, s string) {
+	buf.WriteString(strconv.Itoa(len(s)))
+	buf.WriteByte(':')
+	buf.WriteString(s)
+}
+
+type decoder struct {
+	data []byte
+	pos  int
+	// spans records where each top-level dictionary value starts and ends, so callers can hash
+	// the exact bytes of the info dictionary
+	spans map[string][2]int
+}
+
+func (d *decoder) errorf(msg string, args ...any) error {
+	return errors.Wrap(ErrInvalidBencode, "offset %d: "+msg, append([]any{d.pos}, args...)...)
+}
+
+func (d *decoder) value(depth int) (any, error) {
+	if d.pos >= len(d.data) {
+		return nil, d.errorf("unexpected end of data")
+	}
+
+	switch c := d.data[d.pos]; {
+	case c == 'i':
+		return d.integer()
+	case c >= '0' && c <= '9':
+		return d.string()
+	case c == 'l':
+		return d.list(depth)
+	case c == 'd':
+		return d.dict(depth)
+	default:
+		return nil, d.errorf("unexpected byte %q", c)
+	}
+}
+
+func (d *decoder) integer() (int64, error) {
+	d.pos++ // 'i'
+
+	end := bytes.IndexByte(d.data[d.pos:], 'e')
+	if end < 0 {
+		return 0, d.errorf("unterminated integer")
+	}
+
+	digits := string(d.data[d.pos : d.pos+end])
+	if !canonicalInteger(digits) {
+		return 0, d.errorf("invalid integer %q", digits)
+	}
+
+	n, err := strconv.ParseInt(digits, 10, 64)
+	if err != nil {
+		return 0, d.errorf("integer %q out of range", digits)
+	}
+
+	d.pos += end + 1
+	return n, nil
+}
+
+// canonicalInteger reports whether s is a decimal integer without sign noise or leading zeros
+func canonicalInteger(s string) bool {
+	unsigned := s
+	if len(s) > 0 && s[0] == '-' {
+		unsigned = s[1:]
+		if unsigned == "0" {
+			return false
+		}
+	}
+
+	if unsigned == "" || (len(unsigned) > 1 && unsigned[0] == '0') {
+		return false
+	}
+
+	for i := 0; i < len(unsigned); i++ {
+		if unsigned[i] < '0' || unsigned[i] > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (d *decoder) string() (string, error) {
+	colon := bytes.IndexByte(d.data[d.pos:], ':')
+	if colon < 0 {
+		return "", d.errorf("unterminated string length")
+	}
+
+	digits := string(d.data[d.pos : d.pos+colon])
+	if !canonicalInteger(digits) || digits[0] == '-' {
+		return "", d.errorf("invalid string length %q", digits)
+	}
+
+	length, err := strconv.Atoi(digits)
+	if err != nil || length > len(d.data)-d.pos-colon-1 {
+		return "", d.errorf("string length %s exceeds input", digits)
+	}
+
+	start := d.pos + colon + 1
+	d.pos = start + length
+	return string(d.data[start:d.pos]), nil
+}
+
+func (d *decoder) list(depth int) ([]any, error) {
+	if depth >= maxDepth {
+		return nil, d.errorf("nesting deeper than %d", maxDepth)
+	}
+	d.pos++ // 'l'
+
+	list := []any{}
+	for {
+		if d.pos >= len(d.data) {
+			return nil, d.errorf("unterminated list")
+		}
+		if d.data[d.pos] == 'e' {
+			d.pos++
+			return list, nil
+		}
+
+		item, err := d.value(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, item)
+	}
+}
+
+func (d *decoder) dict(depth int) (map[string]any, error) {
+	if depth >= maxDepth {
+		return nil, d.errorf("nesting deeper than %d", maxDepth)
+	}
+	d.pos++ // 'd'
+
+	dict := map[string]any{}
+	first := true
+	var previous string
+	for {
+		if d.pos >= len(d.data) {
+			return nil, d.errorf("unterminated dictionary")
+		}
+		if d.data[d.pos] == 'e' {
+			d.pos++
+			return dict, nil
+		}
+
+		if c := d.data[d.pos]; c < '0' || c > '9' {
+			return nil, d.errorf("dictionary key is not a string")
+		}
+		key, err := d.string()
+		if err != nil {
+			return nil, err
+		}
+		if !first && key <= previous {
+			return nil, d.errorf("dictionary key %q is duplicated or out of order", key)
+		}
+		first, previous = false, key
+
+		start := d.pos
+		value, err := d.value(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		if depth == 0 && d.spans != nil {
+			d.spans[key] = [2]int{start, d.pos}
+		}
+		dict[key] = value
+	}
+}
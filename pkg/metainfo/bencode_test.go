@@ -0,0 +1,76 @@
+package metainfo
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecode(t *testing.T) {
+	value, err := Decode([]byte("d4:listli-3e0:e3:numi42e3:str5:helloe"))
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]any{
+		"list": []any{int64(-3), ""},
+		"num":  int64(42),
+		"str":  "hello",
+	}, value)
+}
+
+func TestDecode_Strict(t *testing.T) {
+	for name, input := range map[string]string{
+		"empty":              "",
+		"leading zero":       "i03e",
+		"negative zero":      "i-0e",
+		"empty integer":      "ie",
+		"integer overflow":   "i9223372036854775808e",
+		"unterminated int":   "i12",
+		"string too long":    "5:abc",
+		"string length sign": "-1:a",
+		"string leading 0":   "01:a",
+		"unsorted keys":      "d1:bi1e1:ai2ee",
+		"duplicate keys":     "d1:ai1e1:ai2ee",
+		"integer key":        "di1ei2ee",
+		"unterminated list":  "li1e",
+		"trailing data":      "i1ei2e",
+		"unknown type":       "x",
+		"too deep":           strings.Repeat("l", maxDepth+1) + strings.Repeat("e", maxDepth+1),
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := Decode([]byte(input))
+			assert.ErrorIs(t, err, ErrInvalidBencode)
+		})
+	}
+}
+
+func TestEncode(t *testing.T) {
+	encoded, err := Encode(map[string]any{
+		"b": []string{"x", "yz"},
+		"a": []any{1, int64(-2), []byte("raw")},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "d1:ali1ei-2e3:rawe1:bl1:x2:yzee", string(encoded))
+
+	_, err = Encode(1.5)
+	assert.ErrorIs(t, err, ErrInvalidBencode)
+}
+
+func FuzzDecode(f *testing.F) {
+	for _, seed := range []string{"i0e", "i-12e", "4:spam", "le", "de", "d3:cowli1e3:mooee", "l4:spami42ee"} {
+		f.Add([]byte(seed))
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		value, err := Decode(data)
+		if err != nil {
+			return
+		}
+
+		// strict decoding only accepts canonical input, so it must encode back to the same bytes
+		encoded, err := Encode(value)
+		require.NoError(t, err)
+		require.Equal(t, data, encoded)
+	})
+}
@@ -0,0 +1,63 @@
+package metainfo
+
+import (
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// InfoHashes holds the lowercase hex infohashes of a torrent
+type InfoHashes struct {
+	// V1 is the SHA-1 hash of the info dictionary; it is empty for v2-only torrents
+	V1 string
+	// V2 is the SHA-256 hash of the info dictionary; it is empty for v1-only torrents
+	V2 string
+}
+
+// InfoHashes computes the v1 and v2 infohashes from RawInfo.
+func (m *MetaInfo) InfoHashes() InfoHashes {
+	var hashes InfoHashes
+
+	if m.IsV1() {
+		sum := sha1.Sum(m.RawInfo)
+		hashes.V1 = hex.EncodeToString(sum[:])
+	}
+	if m.IsV2() {
+		sum := sha256.Sum256(m.RawInfo)
+		hashes.V2 = hex.EncodeToString(sum[:])
+	}
+
+	return hashes
+}
+
+// ID returns the hash qBittorrent reports for the torrent: the v2 hash truncated to 20 bytes
+// for v2 and hybrid torrents, and the v1 hash otherwise.
+func (h InfoHashes) ID() string {
+	if h.V2 != "" {
+		return h.V2[:2*sha1Size]
+	}
+	return h.V1
+}
+
+// Matches reports whether the hashes reported by a client, such as Torrent.InfohashV1 and
+// Torrent.InfohashV2, identify this torrent. Empty values are not compared, but at least one
+// hash must be present on both sides.
+func (h InfoHashes) Matches(v1, v2 string) bool {
+	compared := false
+
+	if h.V1 != "" && v1 != "" {
+		if !strings.EqualFold(h.V1, v1) {
+			return false
+		}
+		compared = true
+	}
+	if h.V2 != "" && v2 != "" {
+		if !strings.EqualFold(h.V2, v2) {
+			return false
+		}
+		compared = true
+	}
+
+	return compared
+}
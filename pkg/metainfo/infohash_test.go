@@ -0,0 +1,50 @@
+package metainfo
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sampleTorrent is a v1 torrent created by qBittorrent along with the hash it reported
+const (
+	sampleTorrent  = "d10:created by18:qBittorrent v5.1.013:creation datei1747004328e4:infod5:filesld6:lengthi21e4:pathl12:untitled.txteee4:name8:untitled12:piece lengthi16384e6:pieces20:\xb5|\x901\xce\xa3\xdb @$\xce\xbd\xd3\xb0\x0e\xd3\xba\xc0\xcc\xbd7:privatei1eee"
+	sampleInfoHash = "ead9241e611e9712f28b20b151f1a3ecd4a6178a"
+)
+
+func TestInfoHashes_V1(t *testing.T) {
+	m, err := Parse([]byte(sampleTorrent))
+	require.NoError(t, err)
+
+	hashes := m.InfoHashes()
+	assert.Equal(t, InfoHashes{V1: sampleInfoHash}, hashes)
+	assert.Equal(t, sampleInfoHash, hashes.ID())
+	assert.True(t, hashes.Matches(sampleInfoHash, ""))
+	assert.True(t, hashes.Matches("EAD9241E611E9712F28B20B151F1A3ECD4A6178A", ""))
+	assert.False(t, hashes.Matches("", ""))
+	assert.False(t, hashes.Matches("0000000000000000000000000000000000000000", ""))
+}
+
+func TestInfoHashes_Hybrid(t *testing.T) {
+	m, err := Parse(encodeTorrent(t, v2Torrent(true)))
+	require.NoError(t, err)
+
+	sum := sha256.Sum256(m.RawInfo)
+	v2 := hex.EncodeToString(sum[:])
+
+	hashes := m.InfoHashes()
+	assert.Len(t, hashes.V1, 40)
+	assert.Equal(t, v2, hashes.V2)
+	assert.Equal(t, v2[:40], hashes.ID())
+	assert.True(t, hashes.Matches(hashes.V1, v2))
+	assert.True(t, hashes.Matches("", v2))
+	assert.False(t, hashes.Matches(hashes.V1, v2[:40]+v2[:24]))
+
+	v2Only, err := Parse(encodeTorrent(t, v2Torrent(false)))
+	require.NoError(t, err)
+	assert.Empty(t, v2Only.InfoHashes().V1)
+	assert.Len(t, v2Only.InfoHashes().ID(), 40)
+}
@@ -0,0 +1,252 @@
+package metainfo
+
+import (
+	"encoding/base32"
+	"encoding/hex"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/autobrr/go-qbittorrent/errors"
+)
+
+const (
+	magnetPrefix = "magnet:?"
+
+	btihPrefix = "urn:btih:"
+	btmhPrefix = "urn:btmh:"
+	// sha256Multihash prefixes a SHA-256 digest in a btmh multihash
+	sha256Multihash = "1220"
+)
+
+// Magnet is a parsed BEP 9 magnet link. A link may carry a v1 btih hash, a v2 btmh hash or both.
+type Magnet struct {
+	// InfoHashes holds the btih hash as V1 and the btmh hash as V2, in lowercase hex
+	InfoHashes  InfoHashes
+	DisplayName string
+	Trackers    []string
+	// WebSeeds lists the ws parameters
+	WebSeeds []string
+	// Peers lists the x.pe parameters as host:port
+	Peers []string
+	// Select lists the file index ranges of the so parameter
+	Select []FileRange
+}
+
+// FileRange is an inclusive range of file indexes
+type FileRange struct {
+	First int
+	Last  int
+}
+
+// IsMagnetURI reports whether uri looks like a magnet link
+func IsMagnetURI(uri string) bool {
+	return len(uri) >= len(magnetPrefix) && strings.EqualFold(uri[:len(magnetPrefix)], magnetPrefix)
+}
+
+// ParseMagnet parses a magnet link. Base32 btih hashes are converted to hex, and numbered
+// parameters such as tr.1 are treated like their plain form. Errors wrap ErrInvalidMagnet.
+func ParseMagnet(uri string) (*Magnet, error) {
+	if !IsMagnetURI(uri) {
+		return nil, errors.Wrap(ErrInvalidMagnet, "missing %q prefix", magnetPrefix)
+	}
+
+	m := &Magnet{}
+	for param := range strings.SplitSeq(uri[len(magnetPrefix):], "&") {
+		if param == "" {
+			continue
+		}
+
+		rawKey, rawValue, _ := strings.Cut(param, "=")
+		key := strings.ToLower(rawKey)
+		if base, suffix, ok := strings.Cut(key, "."); ok && base != "x" {
+			if _, err := strconv.Atoi(suffix); err == nil {
+				key = base
+			}
+		}
+
+		value, err := url.QueryUnescape(rawValue)
+		if err != nil {
+			return nil, errors.Wrap(ErrInvalidMagnet, "parameter %s is not properly escaped", rawKey)
+		}
+
+		switch key {
+		case "xt":
+			if err := m.parseExactTopic(value); err != nil {
+				return nil, err
+			}
+		case "dn":
+			m.DisplayName = value
+		case "tr":
+			m.Trackers = append(m.Trackers, value)
+		case "ws":
+			m.WebSeeds = append(m.WebSeeds, value)
+		case "x.pe":
+			if _, _, err := net.SplitHostPort(value); err != nil {
+				return nil, errors.Wrap(ErrInvalidMagnet, "invalid peer %q", value)
+			}
+			m.Peers = append(m.Peers, value)
+		case "so":
+			selection, err := parseFileRanges(value)
+			if err != nil {
+				return nil, err
+			}
+			m.Select = append(m.Select, selection...)
+		}
+	}
+
+	if m.InfoHashes.V1 == "" && m.InfoHashes.V2 == "" {
+		return nil, errors.Wrap(ErrInvalidMagnet, "no btih or btmh exact topic")
+	}
+
+	return m, nil
+}
+
+func (m *Magnet) parseExactTopic(value string) error {
+	lower := strings.ToLower(value)
+
+	switch {
+	case strings.HasPrefix(lower, btihPrefix):
+		hash, err := decodeBTIH(value[len(btihPrefix):])
+		if err != nil {
+			return err
+		}
+		if m.InfoHashes.V1 != "" && m.InfoHashes.V1 != hash {
+			return errors.Wrap(ErrInvalidMagnet, "conflicting btih hashes")
+		}
+		m.InfoHashes.V1 = hash
+	case strings.HasPrefix(lower, btmhPrefix):
+		multihash := lower[len(btmhPrefix):]
+		hash, ok := strings.CutPrefix(multihash, sha256Multihash)
+		if !ok || !isHex(hash, sha256Size) {
+			return errors.Wrap(ErrInvalidMagnet, "invalid btmh hash %q", multihash)
+		}
+		if m.InfoHashes.V2 != "" && m.InfoHashes.V2 != hash {
+			return errors.Wrap(ErrInvalidMagnet, "conflicting btmh hashes")
+		}
+		m.InfoHashes.V2 = hash
+	}
+
+	// other exact topics, such as ed2k or sha1 urns, are not BitTorrent hashes and are ignored
+	return nil
+}
+
+// decodeBTIH accepts a 40 character hex or 32 character base32 SHA-1 hash
+func decodeBTIH(hash string) (string, error) {
+	switch len(hash) {
+	case 2 * sha1Size:
+		if isHex(hash, sha1Size) {
+			return strings.ToLower(hash), nil
+		}
+	case 32:
+		decoded, err := base32.StdEncoding.DecodeString(strings.ToUpper(hash))
+		if err == nil {
+			return hex.EncodeToString(decoded), nil
+		}
+	}
+
+	return "", errors.Wrap(ErrInvalidMagnet, "invalid btih hash %q", hash)
+}
+
+func isHex(s string, size int) bool {
+	if len(s) != 2*size {
+		return false
+	}
+	_, err := hex.DecodeString(s)
+	return err == nil
+}
+
+// parseFileRanges parses a BEP 53 selection such as "0,2,4-6"
+func parseFileRanges(value string) ([]FileRange, error) {
+	var ranges []FileRange
+
+	for part := range strings.SplitSeq(value, ",") {
+		first, last, isRange := strings.Cut(part, "-")
+
+		start, err := strconv.Atoi(first)
+		if err != nil || start < 0 {
+			return nil, errors.Wrap(ErrInvalidMagnet, "invalid file selection %q", part)
+		}
+
+		end := start
+		if isRange {
+			end, err = strconv.Atoi(last)
+			if err != nil || end < start {
+				return nil, errors.Wrap(ErrInvalidMagnet, "invalid file selection %q", part)
+			}
+		}
+
+		ranges = append(ranges, FileRange{First: start, Last: end})
+	}
+
+	return ranges, nil
+}
+
+// String renders the magnet in canonical form: btih before btmh, then dn, tr, ws, x.pe and so,
+// with every value query-escaped.
+func (m *Magnet) String() string {
+	var params []string
+	if m.InfoHashes.V1 != "" {
+		params = append(params, "xt="+btihPrefix+m.InfoHashes.V1)
+	}
+	if m.InfoHashes.V2 != "" {
+		params = append(params, "xt="+btmhPrefix+sha256Multihash+m.InfoHashes.V2)
+	}
+	if m.DisplayName != "" {
+		params = append(params, "dn="+escapeMagnetValue(m.DisplayName))
+	}
+	for _, tracker := range m.Trackers {
+		params = append(params, "tr="+escapeMagnetValue(tracker))
+	}
+	for _, seed := range m.WebSeeds {
+		params = append(params, "ws="+escapeMagnetValue(seed))
+	}
+	for _, peer := range m.Peers {
+		params = append(params, "x.pe="+escapeMagnetValue(peer))
+	}
+	if len(m.Select) > 0 {
+		selection := make([]string, 0, len(m.Select))
+		for _, r := range m.Select {
+			if r.First == r.Last {
+				selection = append(selection, strconv.Itoa(r.First))
+			} else {
+				selection = append(selection, strconv.Itoa(r.First)+"-"+strconv.Itoa(r.Last))
+			}
+		}
+		params = append(params, "so="+strings.Join(selection, ","))
+	}
+
+	return magnetPrefix + strings.Join(params, "&")
+}
+
+// escapeMagnetValue query-escapes s using %20 for spaces, which every client understands
+func escapeMagnetValue(s string) string {
+	return strings.ReplaceAll(url.QueryEscape(s), "+", "%20")
+}
+
+// Magnet returns a magnet link for the torrent with its hashes, name, trackers and web seeds.
+func (m *MetaInfo) Magnet() *Magnet {
+	magnet := &Magnet{
+		InfoHashes:  m.InfoHashes(),
+		DisplayName: m.Name,
+		WebSeeds:    m.WebSeeds,
+	}
+
+	seen := make(map[string]struct{})
+	add := func(tracker string) {
+		if _, ok := seen[tracker]; tracker != "" && !ok {
+			seen[tracker] = struct{}{}
+			magnet.Trackers = append(magnet.Trackers, tracker)
+		}
+	}
+
+	add(m.Announce)
+	for _, tier := range m.AnnounceList {
+		for _, tracker := range tier {
+			add(tracker)
+		}
+	}
+
+	return magnet
+}
@@ -0,0 +1,73 @@
+package metainfo
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMagnet(t *testing.T) {
+	v2 := strings.Repeat("ab", sha256Size)
+	uri := "MAGNET:?xt=urn:btih:EAD9241E611E9712F28B20B151F1A3ECD4A6178A&xt=urn:btmh:1220" + v2 +
+		"&dn=Some+Name%21&tr.1=https%3A%2F%2Ft.example%2Fannounce%3Fa%3D1%26b%3D2&tr=udp://u.example:80" +
+		"&ws=https://seed.example/&x.pe=10.0.0.1:6881&x.pe=[::1]:51413&so=0,2,4-6&xl=123"
+
+	m, err := ParseMagnet(uri)
+	require.NoError(t, err)
+
+	assert.Equal(t, InfoHashes{V1: sampleInfoHash, V2: v2}, m.InfoHashes)
+	assert.Equal(t, "Some Name!", m.DisplayName)
+	assert.Equal(t, []string{"https://t.example/announce?a=1&b=2", "udp://u.example:80"}, m.Trackers)
+	assert.Equal(t, []string{"https://seed.example/"}, m.WebSeeds)
+	assert.Equal(t, []string{"10.0.0.1:6881", "[::1]:51413"}, m.Peers)
+	assert.Equal(t, []FileRange{{0, 0}, {2, 2}, {4, 6}}, m.Select)
+
+	canonical := m.String()
+	assert.Equal(t, "magnet:?xt=urn:btih:"+sampleInfoHash+"&xt=urn:btmh:1220"+v2+
+		"&dn=Some%20Name%21&tr=https%3A%2F%2Ft.example%2Fannounce%3Fa%3D1%26b%3D2&tr=udp%3A%2F%2Fu.example%3A80"+
+		"&ws=https%3A%2F%2Fseed.example%2F&x.pe=10.0.0.1%3A6881&x.pe=%5B%3A%3A1%5D%3A51413&so=0,2,4-6", canonical)
+
+	reparsed, err := ParseMagnet(canonical)
+	require.NoError(t, err)
+	assert.Equal(t, m, reparsed)
+}
+
+func TestParseMagnet_Base32(t *testing.T) {
+	m, err := ParseMagnet("magnet:?xt=urn:btih:5lmsihtbd2lrf4ulecyvd4nd5tkkmf4k")
+	require.NoError(t, err)
+	assert.Equal(t, sampleInfoHash, m.InfoHashes.V1)
+	assert.Equal(t, "magnet:?xt=urn:btih:"+sampleInfoHash, m.String())
+}
+
+func TestParseMagnet_Invalid(t *testing.T) {
+	for name, uri := range map[string]string{
+		"not a magnet":     "https://example.com/a.torrent",
+		"no hash":          "magnet:?dn=name",
+		"short btih":       "magnet:?xt=urn:btih:abc",
+		"non-hex btih":     "magnet:?xt=urn:btih:" + strings.Repeat("z", 40),
+		"btmh not sha256":  "magnet:?xt=urn:btmh:1114" + strings.Repeat("a", 40),
+		"conflicting btih": "magnet:?xt=urn:btih:" + sampleInfoHash + "&xt=urn:btih:" + strings.Repeat("0", 40),
+		"bad peer":         "magnet:?xt=urn:btih:" + sampleInfoHash + "&x.pe=nope",
+		"bad selection":    "magnet:?xt=urn:btih:" + sampleInfoHash + "&so=3-1",
+		"bad escape":       "magnet:?xt=urn:btih:" + sampleInfoHash + "&dn=%zz",
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := ParseMagnet(uri)
+			assert.ErrorIs(t, err, ErrInvalidMagnet)
+		})
+	}
+}
+
+func TestMetaInfo_Magnet(t *testing.T) {
+	torrent := v1MultiFileTorrent()
+	m, err := Parse(encodeTorrent(t, torrent))
+	require.NoError(t, err)
+
+	magnet := m.Magnet()
+	assert.Equal(t, m.InfoHashes(), magnet.InfoHashes)
+	assert.Equal(t, "Show", magnet.DisplayName)
+	assert.Equal(t, []string{"https://tracker.example/announce", "udp://backup.example:80"}, magnet.Trackers)
+	assert.Equal(t, []string{"https://seed.example/files/"}, magnet.WebSeeds)
+}
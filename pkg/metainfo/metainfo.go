@@ -0,0 +1,463 @@
+// Package metainfo decodes bencoded .torrent files into a typed MetaInfo without talking to qBittorrent.
+package metainfo
+
+import (
+	"math"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/autobrr/go-qbittorrent/errors"
+)
+
+const (
+	// minV2PieceLength is the smallest piece length BEP 52 allows
+	minV2PieceLength = 16 * 1024
+
+	sha1Size   = 20
+	sha256Size = 32
+)
+
+// MetaInfo is a parsed .torrent file. Version 1, version 2 and hybrid torrents are supported.
+type MetaInfo struct {
+	Name        string
+	PieceLength int64
+	// PieceCount is the number of v1 piece hashes; it is zero for v2-only torrents
+	PieceCount int
+	Private    bool
+	Source     string
+	// MetaVersion is 2 for v2 and hybrid torrents and 1 otherwise
+	MetaVersion  int
+	Announce     string
+	AnnounceList [][]string
+	// WebSeeds lists the BEP 19 url-list entries
+	WebSeeds     []string
+	Comment      string
+	CreatedBy    string
+	CreationDate time.Time
+	// Files lists the content files in torrent order. For v1 and hybrid torrents they come from the
+	// v1 file list, including BEP 47 padding files; for v2-only torrents they come from FileTree.
+	Files []File
+	// FileTree lists the files of the v2 file tree in path order; it is empty for v1-only torrents
+	FileTree []TreeFile
+	// RawInfo holds the bencoded info dictionary exactly as it appeared in the input
+	RawInfo []byte
+
+	v1 bool
+}
+
+// File is one file of a torrent
+type File struct {
+	// Path is relative to the torrent directory; single-file torrents use the name as their only element
+	Path    []string
+	Length  int64
+	Padding bool
+}
+
+// TreeFile is one file of a v2 file tree
+type TreeFile struct {
+	Path   []string
+	Length int64
+	// PiecesRoot is the SHA-256 merkle root of the file; it is empty for empty files
+	PiecesRoot []byte
+}
+
+// IsV1 reports whether the torrent carries v1 piece hashes
+func (m *MetaInfo) IsV1() bool {
+	return m.v1
+}
+
+// IsV2 reports whether the torrent carries a v2 file tree
+func (m *MetaInfo) IsV2() bool {
+	return m.MetaVersion == 2
+}
+
+// IsHybrid reports whether the torrent is both a v1 and a v2 torrent
+func (m *MetaInfo) IsHybrid() bool {
+	return m.IsV1() && m.IsV2()
+}
+
+// TotalLength returns the combined length of all files, excluding padding files
+func (m *MetaInfo) TotalLength() int64 {
+	var total int64
+	for _, file := range m.Files {
+		if !file.Padding {
+			total += file.Length
+		}
+	}
+	return total
+}
+
+// ParseFile reads and parses the .torrent file at path.
+func ParseFile(path string) (*MetaInfo, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read torrent file")
+	}
+	return Parse(data)
+}
+
+// Parse decodes a .torrent file. Errors wrap ErrInvalidBencode when the data is not strict
+// bencode and ErrInvalidMetaInfo when it is not a valid torrent.
+func Parse(data []byte) (*MetaInfo, error) {
+	d := &decoder{data: data, spans: make(map[string][2]int)}
+
+	value, err := d.value(0)
+	if err != nil {
+		return nil, err
+	}
+	if d.pos != len(d.data) {
+		return nil, d.errorf("trailing data")
+	}
+
+	root, ok := value.(map[string]any)
+	if !ok {
+		return nil, errors.Wrap(ErrInvalidMetaInfo, "top level is not a dictionary")
+	}
+
+	info, ok := root["info"].(map[string]any)
+	if !ok {
+		return nil, errors.Wrap(ErrInvalidMetaInfo, "missing info dictionary")
+	}
+
+	span := d.spans["info"]
+	m := &MetaInfo{RawInfo: slices.Clone(data[span[0]:span[1]])}
+
+	if err := m.parseRoot(root); err != nil {
+		return nil, err
+	}
+	if err := m.parseInfo(info); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+func (m *MetaInfo) parseRoot(root map[string]any) error {
+	var err error
+
+	if m.Announce, err = optionalString(root, "announce"); err != nil {
+		return err
+	}
+	if m.Comment, err = optionalString(root, "comment"); err != nil {
+		return err
+	}
+	if m.CreatedBy, err = optionalString(root, "created by"); err != nil {
+		return err
+	}
+
+	if value, ok := root["creation date"]; ok {
+		seconds, ok := value.(int64)
+		if !ok {
+			return errors.Wrap(ErrInvalidMetaInfo, "creation date is not an integer")
+		}
+		m.CreationDate = time.Unix(seconds, 0).UTC()
+	}
+
+	if value, ok := root["announce-list"]; ok {
+		tiers, ok := value.([]any)
+		if !ok {
+			return errors.Wrap(ErrInvalidMetaInfo, "announce-list is not a list")
+		}
+		for i, tier := range tiers {
+			urls, err := stringList(tier)
+			if err != nil {
+				return errors.Wrap(err, "announce-list tier %d", i)
+			}
+			if len(urls) > 0 {
+				m.AnnounceList = append(m.AnnounceList, urls)
+			}
+		}
+	}
+
+	switch value := root["url-list"].(type) {
+	case nil:
+	case string:
+		if value != "" {
+			m.WebSeeds = []string{value}
+		}
+	default:
+		if m.WebSeeds, err = stringList(value); err != nil {
+			return errors.Wrap(err, "url-list")
+		}
+	}
+
+	return nil
+}
+
+func (m *MetaInfo) parseInfo(info map[string]any) error {
+	name, ok := info["name"].(string)
+	if !ok || !validPathComponent(name) {
+		return errors.Wrap(ErrInvalidMetaInfo, "invalid name %q", name)
+	}
+	m.Name = name
+
+	pieceLength, ok := info["piece length"].(int64)
+	if !ok || pieceLength <= 0 {
+		return errors.Wrap(ErrInvalidMetaInfo, "invalid piece length")
+	}
+	m.PieceLength = pieceLength
+
+	if value, ok := info["private"]; ok {
+		private, ok := value.(int64)
+		if !ok || (private != 0 && private != 1) {
+			return errors.Wrap(ErrInvalidMetaInfo, "private flag must be 0 or 1")
+		}
+		m.Private = private == 1
+	}
+
+	var err error
+	if m.Source, err = optionalString(info, "source"); err != nil {
+		return err
+	}
+
+	if value, ok := info["meta version"]; ok {
+		version, ok := value.(int64)
+		if !ok || version != 2 {
+			return errors.Wrap(ErrInvalidMetaInfo, "unsupported meta version %v", value)
+		}
+		m.MetaVersion = 2
+		if err := m.parseFileTree(info); err != nil {
+			return err
+		}
+	} else {
+		m.MetaVersion = 1
+	}
+
+	_, hasPieces := info["pieces"]
+	if hasPieces || m.MetaVersion == 1 {
+		if err := m.parseV1(info); err != nil {
+			return err
+		}
+	} else {
+		m.Files = make([]File, 0, len(m.FileTree))
+		for _, file := range m.FileTree {
+			m.Files = append(m.Files, File{Path: file.Path, Length: file.Length})
+		}
+	}
+
+	if m.IsHybrid() {
+		return m.checkHybridFiles()
+	}
+
+	return nil
+}
+
+func (m *MetaInfo) parseV1(info map[string]any) error {
+	pieces, ok := info["pieces"].(string)
+	if !ok || len(pieces)%sha1Size != 0 {
+		return errors.Wrap(ErrInvalidMetaInfo, "pieces must be a multiple of %d bytes", sha1Size)
+	}
+	m.PieceCount = len(pieces) / sha1Size
+	m.v1 = true
+
+	length, hasLength := info["length"]
+	files, hasFiles := info["files"]
+
+	switch {
+	case hasLength && hasFiles:
+		return errors.Wrap(ErrInvalidMetaInfo, "info has both length and files")
+	case hasLength:
+		n, ok := length.(int64)
+		if !ok || n < 0 {
+			return errors.Wrap(ErrInvalidMetaInfo, "invalid length")
+		}
+		m.Files = []File{{Path: []string{m.Name}, Length: n}}
+	case hasFiles:
+		list, ok := files.([]any)
+		if !ok || len(list) == 0 {
+			return errors.Wrap(ErrInvalidMetaInfo, "files must be a non-empty list")
+		}
+		for i, item := range list {
+			file, err := parseV1File(item)
+			if err != nil {
+				return errors.Wrap(err, "file %d", i)
+			}
+			m.Files = append(m.Files, file)
+		}
+	default:
+		return errors.Wrap(ErrInvalidMetaInfo, "info has neither length nor files")
+	}
+
+	// padding files count towards the piece layout even though they are not content
+	var total int64
+	for _, file := range m.Files {
+		if file.Length > math.MaxInt64-total {
+			return errors.Wrap(ErrInvalidMetaInfo, "total length overflows")
+		}
+		total += file.Length
+	}
+
+	expected := total / m.PieceLength
+	if total%m.PieceLength != 0 {
+		expected++
+	}
+	if int64(m.PieceCount) != expected {
+		return errors.Wrap(ErrInvalidMetaInfo, "expected %d pieces for %d bytes, got %d", expected, total, m.PieceCount)
+	}
+
+	return nil
+}
+
+func parseV1File(item any) (File, error) {
+	dict, ok := item.(map[string]any)
+	if !ok {
+		return File{}, errors.Wrap(ErrInvalidMetaInfo, "file is not a dictionary")
+	}
+
+	length, ok := dict["length"].(int64)
+	if !ok || length < 0 {
+		return File{}, errors.Wrap(ErrInvalidMetaInfo, "invalid file length")
+	}
+
+	path, err := stringList(dict["path"])
+	if err != nil || !validPath(path) {
+		return File{}, errors.Wrap(ErrInvalidMetaInfo, "invalid file path")
+	}
+
+	attr, err := optionalString(dict, "attr")
+	if err != nil {
+		return File{}, err
+	}
+
+	return File{Path: path, Length: length, Padding: strings.Contains(attr, "p")}, nil
+}
+
+func (m *MetaInfo) parseFileTree(info map[string]any) error {
+	if m.PieceLength < minV2PieceLength || m.PieceLength&(m.PieceLength-1) != 0 {
+		return errors.Wrap(ErrInvalidMetaInfo, "v2 piece length must be a power of two of at least %d", minV2PieceLength)
+	}
+
+	tree, ok := info["file tree"].(map[string]any)
+	if !ok || len(tree) == 0 {
+		return errors.Wrap(ErrInvalidMetaInfo, "missing file tree")
+	}
+
+	return m.walkFileTree(tree, nil)
+}
+
+func (m *MetaInfo) walkFileTree(node map[string]any, parent []string) error {
+	keys := make([]string, 0, len(node))
+	for key := range node {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	for _, key := range keys {
+		child, ok := node[key].(map[string]any)
+		if !ok {
+			return errors.Wrap(ErrInvalidMetaInfo, "file tree entry %q is not a dictionary", key)
+		}
+
+		if key == "" {
+			if len(parent) == 0 {
+				return errors.Wrap(ErrInvalidMetaInfo, "file tree has a file without a name")
+			}
+			file, err := parseTreeFile(child, parent)
+			if err != nil {
+				return err
+			}
+			m.FileTree = append(m.FileTree, file)
+			continue
+		}
+
+		if !validPathComponent(key) {
+			return errors.Wrap(ErrInvalidMetaInfo, "invalid file tree path component %q", key)
+		}
+		if len(child) == 0 {
+			return errors.Wrap(ErrInvalidMetaInfo, "file tree directory %q is empty", key)
+		}
+
+		path := append(slices.Clip(parent), key)
+		if err := m.walkFileTree(child, path); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func parseTreeFile(leaf map[string]any, path []string) (TreeFile, error) {
+	name := strings.Join(path, "/")
+
+	length, ok := leaf["length"].(int64)
+	if !ok || length < 0 {
+		return TreeFile{}, errors.Wrap(ErrInvalidMetaInfo, "file tree entry %q has an invalid length", name)
+	}
+
+	file := TreeFile{Path: path, Length: length}
+	if length == 0 {
+		return file, nil
+	}
+
+	root, ok := leaf["pieces root"].(string)
+	if !ok || len(root) != sha256Size {
+		return TreeFile{}, errors.Wrap(ErrInvalidMetaInfo, "file tree entry %q has an invalid pieces root", name)
+	}
+	file.PiecesRoot = []byte(root)
+
+	return file, nil
+}
+
+// checkHybridFiles verifies that the v1 file list describes the same content as the v2 file tree
+func (m *MetaInfo) checkHybridFiles() error {
+	var content []File
+	for _, file := range m.Files {
+		if !file.Padding {
+			content = append(content, file)
+		}
+	}
+
+	if len(content) != len(m.FileTree) {
+		return errors.Wrap(ErrInvalidMetaInfo, "hybrid torrent has %d v1 files but %d v2 files", len(content), len(m.FileTree))
+	}
+
+	for i, file := range content {
+		tree := m.FileTree[i]
+		if file.Length != tree.Length || !slices.Equal(file.Path, tree.Path) {
+			return errors.Wrap(ErrInvalidMetaInfo, "hybrid torrent file %q does not match the v2 file tree", strings.Join(file.Path, "/"))
+		}
+	}
+
+	return nil
+}
+
+func optionalString(dict map[string]any, key string) (string, error) {
+	value, ok := dict[key]
+	if !ok {
+		return "", nil
+	}
+
+	s, ok := value.(string)
+	if !ok {
+		return "", errors.Wrap(ErrInvalidMetaInfo, "%s is not a string", key)
+	}
+	return s, nil
+}
+
+func stringList(value any) ([]string, error) {
+	list, ok := value.([]any)
+	if !ok {
+		return nil, errors.Wrap(ErrInvalidMetaInfo, "not a list")
+	}
+
+	result := make([]string, 0, len(list))
+	for _, item := range list {
+		s, ok := item.(string)
+		if !ok {
+			return nil, errors.Wrap(ErrInvalidMetaInfo, "list item is not a string")
+		}
+		result = append(result, s)
+	}
+	return result, nil
+}
+
+func validPath(path []string) bool {
+	return len(path) > 0 && !slices.ContainsFunc(path, func(c string) bool { return !validPathComponent(c) })
+}
+
+// validPathComponent rejects components that could escape the torrent directory
+func validPathComponent(c string) bool {
+	return c != "" && c != "." && c != ".." && !strings.ContainsAny(c, "/\\\x00")
+}
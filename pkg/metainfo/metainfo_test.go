@@ -0,0 +1,180 @@
+package metainfo
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func encodeTorrent(t testing.TB, torrent map[string]any) []byte {
+	t.Helper()
+	data, err := Encode(torrent)
+	require.NoError(t, err)
+	return data
+}
+
+func v1MultiFileTorrent() map[string]any {
+	return map[string]any{
+		"announce":      "https://tracker.example/announce",
+		"announce-list": []any{[]any{"https://tracker.example/announce"}, []any{}, []any{"udp://backup.example:80"}},
+		"url-list":      "https://seed.example/files/",
+		"comment":       "test",
+		"created by":    "go-qbittorrent",
+		"creation date": 1700000000,
+		"info": map[string]any{
+			"name":         "Show",
+			"piece length": 16384,
+			"pieces":       strings.Repeat("a", 2*sha1Size),
+			"private":      1,
+			"source":       "EXAMPLE",
+			"files": []any{
+				map[string]any{"length": 10000, "path": []any{"Season 1", "e01.mkv"}},
+				map[string]any{"length": 6384, "path": []any{".pad", "6384"}, "attr": "p"},
+				map[string]any{"length": 100, "path": []any{"e02.mkv"}},
+			},
+		},
+	}
+}
+
+func v2Torrent(hybrid bool) map[string]any {
+	info := map[string]any{
+		"name":         "file.bin",
+		"piece length": 16384,
+		"meta version": 2,
+		"file tree": map[string]any{
+			"file.bin": map[string]any{"": map[string]any{"length": 20000, "pieces root": strings.Repeat("r", sha256Size)}},
+		},
+	}
+	if hybrid {
+		info["length"] = 20000
+		info["pieces"] = strings.Repeat("a", 2*sha1Size)
+	}
+
+	return map[string]any{"info": info, "url-list": []any{"https://a.example/", "https://b.example/"}}
+}
+
+func TestParse_V1(t *testing.T) {
+	m, err := Parse(encodeTorrent(t, v1MultiFileTorrent()))
+	require.NoError(t, err)
+
+	assert.Equal(t, "Show", m.Name)
+	assert.Equal(t, int64(16384), m.PieceLength)
+	assert.Equal(t, 2, m.PieceCount)
+	assert.True(t, m.Private)
+	assert.Equal(t, "EXAMPLE", m.Source)
+	assert.Equal(t, [][]string{{"https://tracker.example/announce"}, {"udp://backup.example:80"}}, m.AnnounceList)
+	assert.Equal(t, []string{"https://seed.example/files/"}, m.WebSeeds)
+	assert.Equal(t, time.Unix(1700000000, 0).UTC(), m.CreationDate)
+	assert.Equal(t, []File{
+		{Path: []string{"Season 1", "e01.mkv"}, Length: 10000},
+		{Path: []string{".pad", "6384"}, Length: 6384, Padding: true},
+		{Path: []string{"e02.mkv"}, Length: 100},
+	}, m.Files)
+	assert.Equal(t, int64(10100), m.TotalLength())
+	assert.True(t, m.IsV1())
+	assert.False(t, m.IsV2())
+	assert.Empty(t, m.FileTree)
+	assert.True(t, strings.HasPrefix(string(m.RawInfo), "d5:filesl"))
+}
+
+func TestParse_V2AndHybrid(t *testing.T) {
+	v2, err := Parse(encodeTorrent(t, v2Torrent(false)))
+	require.NoError(t, err)
+	assert.True(t, v2.IsV2())
+	assert.False(t, v2.IsV1())
+	assert.Equal(t, []TreeFile{{Path: []string{"file.bin"}, Length: 20000, PiecesRoot: []byte(strings.Repeat("r", sha256Size))}}, v2.FileTree)
+	assert.Equal(t, []File{{Path: []string{"file.bin"}, Length: 20000}}, v2.Files)
+	assert.Equal(t, []string{"https://a.example/", "https://b.example/"}, v2.WebSeeds)
+
+	hybrid, err := Parse(encodeTorrent(t, v2Torrent(true)))
+	require.NoError(t, err)
+	assert.True(t, hybrid.IsHybrid())
+	assert.Equal(t, 2, hybrid.PieceCount)
+}
+
+func TestParse_Invalid(t *testing.T) {
+	for name, mutate := range map[string]func(torrent map[string]any){
+		"missing info":        func(torrent map[string]any) { delete(torrent, "info") },
+		"traversal in path":   func(torrent map[string]any) { fileAt(torrent, 0)["path"] = []any{"..", "x"} },
+		"empty path":          func(torrent map[string]any) { fileAt(torrent, 0)["path"] = []any{} },
+		"negative length":     func(torrent map[string]any) { fileAt(torrent, 0)["length"] = -1 },
+		"piece count":         func(torrent map[string]any) { infoOf(torrent)["pieces"] = strings.Repeat("a", sha1Size) },
+		"ragged pieces":       func(torrent map[string]any) { infoOf(torrent)["pieces"] = "abc" },
+		"zero piece length":   func(torrent map[string]any) { infoOf(torrent)["piece length"] = 0 },
+		"private flag":        func(torrent map[string]any) { infoOf(torrent)["private"] = 2 },
+		"length and files":    func(torrent map[string]any) { infoOf(torrent)["length"] = 1 },
+		"name with separator": func(torrent map[string]any) { infoOf(torrent)["name"] = "a/b" },
+		"announce-list type":  func(torrent map[string]any) { torrent["announce-list"] = []any{"flat"} },
+		"meta version":        func(torrent map[string]any) { infoOf(torrent)["meta version"] = 3 },
+	} {
+		t.Run(name, func(t *testing.T) {
+			torrent := v1MultiFileTorrent()
+			mutate(torrent)
+
+			_, err := Parse(encodeTorrent(t, torrent))
+			assert.ErrorIs(t, err, ErrInvalidMetaInfo)
+		})
+	}
+
+	for name, mutate := range map[string]func(info map[string]any){
+		"missing pieces root": func(info map[string]any) {
+			info["file tree"] = map[string]any{"file.bin": map[string]any{"": map[string]any{"length": 1}}}
+		},
+		"piece length not power of two": func(info map[string]any) { info["piece length"] = 20000 },
+		"hybrid mismatch":               func(info map[string]any) { info["length"] = 19999 },
+	} {
+		t.Run(name, func(t *testing.T) {
+			torrent := v2Torrent(true)
+			mutate(infoOf(torrent))
+
+			_, err := Parse(encodeTorrent(t, torrent))
+			assert.ErrorIs(t, err, ErrInvalidMetaInfo)
+		})
+	}
+
+	_, err := Parse([]byte("d4:infod4:name1:aee1:x"))
+	assert.ErrorIs(t, err, ErrInvalidBencode)
+}
+
+func TestParseFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.torrent")
+	require.NoError(t, os.WriteFile(path, encodeTorrent(t, v2Torrent(true)), 0o644))
+
+	m, err := ParseFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "file.bin", m.Name)
+}
+
+func infoOf(torrent map[string]any) map[string]any {
+	return torrent["info"].(map[string]any)
+}
+
+func fileAt(torrent map[string]any, i int) map[string]any {
+	return infoOf(torrent)["files"].([]any)[i].(map[string]any)
+}
+
+func FuzzParse(f *testing.F) {
+	f.Add(encodeTorrent(f, v1MultiFileTorrent()))
+	f.Add(encodeTorrent(f, v2Torrent(false)))
+	f.Add(encodeTorrent(f, v2Torrent(true)))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		m, err := Parse(data)
+		if err != nil {
+			return
+		}
+
+		require.NotEmpty(t, m.Name)
+		require.Positive(t, m.PieceLength)
+		require.NotEmpty(t, m.Files)
+
+		info, err := Decode(m.RawInfo)
+		require.NoError(t, err)
+		require.IsType(t, map[string]any{}, info)
+	})
+}
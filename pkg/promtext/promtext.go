@@ -0,0 +1,118 @@
+package promtext
+
+import (
+	"bufio"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ContentType is the media type of the Prometheus text exposition format
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// DefaultBuckets are the upper bounds, in seconds, of latency histograms
+var DefaultBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+// Label is a metric label
+type Label struct {
+	Name  string
+	Value string
+}
+
+// Writer writes metric families in the Prometheus text exposition format.
+// The first write error is kept and returned by Flush.
+type Writer struct {
+	w   *bufio.Writer
+	err error
+}
+
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{w: bufio.NewWriter(w)}
+}
+
+// Family starts a metric family. typ is counter, gauge, histogram or untyped.
+func (w *Writer) Family(name, help, typ string) {
+	w.write("# HELP ", name, " ", helpEscaper.Replace(help), "\n")
+	w.write("# TYPE ", name, " ", typ, "\n")
+}
+
+// Sample writes a sample of the current family
+func (w *Writer) Sample(name string, labels []Label, value float64) {
+	w.write(name)
+	if len(labels) > 0 {
+		w.write("{")
+		for i, label := range labels {
+			if i > 0 {
+				w.write(",")
+			}
+			w.write(label.Name, `="`, labelEscaper.Replace(label.Value), `"`)
+		}
+		w.write("}")
+	}
+	w.write(" ", FormatValue(value), "\n")
+}
+
+// Histogram writes the samples of a histogram. counts holds the number of observations in each
+// bucket, not cumulated, followed by the number above the last bound.
+func (w *Writer) Histogram(name string, labels []Label, bounds []float64, counts []uint64, sum float64) {
+	bucketLabels := append(labels[:len(labels):len(labels)], Label{Name: "le"})
+
+	var cumulative uint64
+	for i, bound := range bounds {
+		cumulative += counts[i]
+		bucketLabels[len(labels)].Value = FormatValue(bound)
+		w.Sample(name+"_bucket", bucketLabels, float64(cumulative))
+	}
+	cumulative += counts[len(bounds)]
+	bucketLabels[len(labels)].Value = "+Inf"
+	w.Sample(name+"_bucket", bucketLabels, float64(cumulative))
+
+	w.Sample(name+"_sum", labels, sum)
+	w.Sample(name+"_count", labels, float64(cumulative))
+}
+
+// Flush writes any buffered data and returns the first error encountered
+func (w *Writer) Flush() error {
+	if w.err != nil {
+		return w.err
+	}
+	return w.w.Flush()
+}
+
+func (w *Writer) write(parts ...string) {
+	for _, part := range parts {
+		if w.err != nil {
+			return
+		}
+		_, w.err = w.w.WriteString(part)
+	}
+}
+
+// FormatValue formats a sample value or bucket bound
+func FormatValue(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	case math.IsNaN(v):
+		return "NaN"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+// BucketIndex returns the index of the first bound v fits under, or len(bounds) if none
+func BucketIndex(bounds []float64, v float64) int {
+	for i, bound := range bounds {
+		if v <= bound {
+			return i
+		}
+	}
+	return len(bounds)
+}
+
+var (
+	helpEscaper  = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+	labelEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+)
@@ -0,0 +1,42 @@
+package promtext
+
+import (
+	"bytes"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+
+	w.Family("requests_total", "Requests.\nBy path \\ code.", "counter")
+	w.Sample("requests_total", []Label{{Name: "path", Value: "a\"b\\c\nd"}}, 3)
+	w.Sample("requests_total", nil, math.Inf(1))
+	w.Family("latency_seconds", "Latency.", "histogram")
+	w.Histogram("latency_seconds", []Label{{Name: "path", Value: "x"}}, []float64{0.1, 1}, []uint64{2, 0, 1}, 2.5)
+	require.NoError(t, w.Flush())
+
+	assert.Equal(t, `# HELP requests_total Requests.\nBy path \\ code.
+# TYPE requests_total counter
+requests_total{path="a\"b\\c\nd"} 3
+requests_total +Inf
+# HELP latency_seconds Latency.
+# TYPE latency_seconds histogram
+latency_seconds_bucket{path="x",le="0.1"} 2
+latency_seconds_bucket{path="x",le="1"} 2
+latency_seconds_bucket{path="x",le="+Inf"} 3
+latency_seconds_sum{path="x"} 2.5
+latency_seconds_count{path="x"} 3
+`, buf.String())
+}
+
+func TestBucketIndex(t *testing.T) {
+	bounds := []float64{0.1, 1}
+	assert.Equal(t, 0, BucketIndex(bounds, 0.1))
+	assert.Equal(t, 1, BucketIndex(bounds, 0.5))
+	assert.Equal(t, 2, BucketIndex(bounds, 5))
+}
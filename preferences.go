@@ -0,0 +1,105 @@
+//go:generate go run internal/codegen/generate_preferences_patch.go
+
+package qbittorrent
+
+import (
+	"context"
+	"encoding/json"
+	"maps"
+	"slices"
+)
+
+// PreferencesPatch is a partial update of AppPreferences.
+// Only the fields set through its Set* methods are sent to qBittorrent, keyed by their JSON names.
+type PreferencesPatch struct {
+	values map[string]interface{}
+}
+
+// NewPreferencesPatch returns an empty patch.
+func NewPreferencesPatch() *PreferencesPatch {
+	return &PreferencesPatch{values: make(map[string]interface{})}
+}
+
+func (p *PreferencesPatch) set(key string, value interface{}) *PreferencesPatch {
+	if p.values == nil {
+		p.values = make(map[string]interface{})
+	}
+	p.values[key] = value
+	return p
+}
+
+// Len returns the number of preferences set in the patch.
+func (p *PreferencesPatch) Len() int {
+	if p == nil {
+		return 0
+	}
+	return len(p.values)
+}
+
+// IsEmpty reports whether the patch sets no preferences.
+func (p *PreferencesPatch) IsEmpty() bool {
+	return p.Len() == 0
+}
+
+// Keys returns the JSON keys set in the patch in sorted order.
+func (p *PreferencesPatch) Keys() []string {
+	if p == nil {
+		return nil
+	}
+	return slices.Sorted(maps.Keys(p.values))
+}
+
+// Get returns the value set for the JSON key, if any.
+func (p *PreferencesPatch) Get(key string) (interface{}, bool) {
+	if p == nil {
+		return nil, false
+	}
+	v, ok := p.values[key]
+	return v, ok
+}
+
+// Delete removes the JSON key from the patch.
+func (p *PreferencesPatch) Delete(key string) *PreferencesPatch {
+	if p != nil {
+		delete(p.values, key)
+	}
+	return p
+}
+
+// Merge copies every value set in other into p, overwriting keys set in both.
+func (p *PreferencesPatch) Merge(other *PreferencesPatch) *PreferencesPatch {
+	if other == nil {
+		return p
+	}
+	for k, v := range other.values {
+		p.set(k, v)
+	}
+	return p
+}
+
+// Map returns a copy of the patch suitable for SetPreferencesCtx.
+func (p *PreferencesPatch) Map() map[string]interface{} {
+	if p == nil {
+		return map[string]interface{}{}
+	}
+	return maps.Clone(p.values)
+}
+
+func (p *PreferencesPatch) MarshalJSON() ([]byte, error) {
+	return json.Marshal(p.Map())
+}
+
+// ApplyPreferencesPatch sends only the preferences set in patch.
+func (c *Client) ApplyPreferencesPatch(patch *PreferencesPatch) error {
+	return c.ApplyPreferencesPatchCtx(context.Background(), patch)
+}
+
+// ApplyPreferencesPatchCtx sends only the preferences set in patch.
+// An empty patch is a no-op and does not contact the server.
+func (c *Client) ApplyPreferencesPatchCtx(ctx context.Context, patch *PreferencesPatch) error {
+	if patch.IsEmpty() {
+		return nil
+	}
+
+	return c.SetPreferencesCtx(ctx, patch.Map())
+}
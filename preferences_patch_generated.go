@@ -0,0 +1,1418 @@
+// Code generated by go generate; DO NOT EDIT.
+// This file was generated by internal/codegen/generate_preferences_patch.go
+
+package qbittorrent
+
+import (
+	"maps"
+	"reflect"
+)
+
+// SetAddTrackers sets the add_trackers preference (AppPreferences.AddTrackers).
+func (p *PreferencesPatch) SetAddTrackers(v string) *PreferencesPatch {
+	return p.set("add_trackers", v)
+}
+
+// SetAddTrackersEnabled sets the add_trackers_enabled preference (AppPreferences.AddTrackersEnabled).
+func (p *PreferencesPatch) SetAddTrackersEnabled(v bool) *PreferencesPatch {
+	return p.set("add_trackers_enabled", v)
+}
+
+// SetAltDlLimit sets the alt_dl_limit preference (AppPreferences.AltDlLimit).
+func (p *PreferencesPatch) SetAltDlLimit(v int) *PreferencesPatch {
+	return p.set("alt_dl_limit", v)
+}
+
+// SetAltUpLimit sets the alt_up_limit preference (AppPreferences.AltUpLimit).
+func (p *PreferencesPatch) SetAltUpLimit(v int) *PreferencesPatch {
+	return p.set("alt_up_limit", v)
+}
+
+// SetAlternativeWebuiEnabled sets the alternative_webui_enabled preference (AppPreferences.AlternativeWebuiEnabled).
+func (p *PreferencesPatch) SetAlternativeWebuiEnabled(v bool) *PreferencesPatch {
+	return p.set("alternative_webui_enabled", v)
+}
+
+// SetAlternativeWebuiPath sets the alternative_webui_path preference (AppPreferences.AlternativeWebuiPath).
+func (p *PreferencesPatch) SetAlternativeWebuiPath(v string) *PreferencesPatch {
+	return p.set("alternative_webui_path", v)
+}
+
+// SetAnnounceIP sets the announce_ip preference (AppPreferences.AnnounceIP).
+func (p *PreferencesPatch) SetAnnounceIP(v string) *PreferencesPatch {
+	return p.set("announce_ip", v)
+}
+
+// SetAnnounceToAllTiers sets the announce_to_all_tiers preference (AppPreferences.AnnounceToAllTiers).
+func (p *PreferencesPatch) SetAnnounceToAllTiers(v bool) *PreferencesPatch {
+	return p.set("announce_to_all_tiers", v)
+}
+
+// SetAnnounceToAllTrackers sets the announce_to_all_trackers preference (AppPreferences.AnnounceToAllTrackers).
+func (p *PreferencesPatch) SetAnnounceToAllTrackers(v bool) *PreferencesPatch {
+	return p.set("announce_to_all_trackers", v)
+}
+
+// SetAnonymousMode sets the anonymous_mode preference (AppPreferences.AnonymousMode).
+func (p *PreferencesPatch) SetAnonymousMode(v bool) *PreferencesPatch {
+	return p.set("anonymous_mode", v)
+}
+
+// SetAsyncIoThreads sets the async_io_threads preference (AppPreferences.AsyncIoThreads).
+func (p *PreferencesPatch) SetAsyncIoThreads(v int) *PreferencesPatch {
+	return p.set("async_io_threads", v)
+}
+
+// SetAutoDeleteMode sets the auto_delete_mode preference (AppPreferences.AutoDeleteMode).
+func (p *PreferencesPatch) SetAutoDeleteMode(v int) *PreferencesPatch {
+	return p.set("auto_delete_mode", v)
+}
+
+// SetAutoTmmEnabled sets the auto_tmm_enabled preference (AppPreferences.AutoTmmEnabled).
+func (p *PreferencesPatch) SetAutoTmmEnabled(v bool) *PreferencesPatch {
+	return p.set("auto_tmm_enabled", v)
+}
+
+// SetAutorunEnabled sets the autorun_enabled preference (AppPreferences.AutorunEnabled).
+func (p *PreferencesPatch) SetAutorunEnabled(v bool) *PreferencesPatch {
+	return p.set("autorun_enabled", v)
+}
+
+// SetAutorunOnTorrentAddedEnabled sets the autorun_on_torrent_added_enabled preference (AppPreferences.AutorunOnTorrentAddedEnabled).
+func (p *PreferencesPatch) SetAutorunOnTorrentAddedEnabled(v bool) *PreferencesPatch {
+	return p.set("autorun_on_torrent_added_enabled", v)
+}
+
+// SetAutorunOnTorrentAddedProgram sets the autorun_on_torrent_added_program preference (AppPreferences.AutorunOnTorrentAddedProgram).
+func (p *PreferencesPatch) SetAutorunOnTorrentAddedProgram(v string) *PreferencesPatch {
+	return p.set("autorun_on_torrent_added_program", v)
+}
+
+// SetAutorunProgram sets the autorun_program preference (AppPreferences.AutorunProgram).
+func (p *PreferencesPatch) SetAutorunProgram(v string) *PreferencesPatch {
+	return p.set("autorun_program", v)
+}
+
+// SetBannedIPs sets the banned_IPs preference (AppPreferences.BannedIPs).
+func (p *PreferencesPatch) SetBannedIPs(v string) *PreferencesPatch {
+	return p.set("banned_IPs", v)
+}
+
+// SetBittorrentProtocol sets the bittorrent_protocol preference (AppPreferences.BittorrentProtocol).
+func (p *PreferencesPatch) SetBittorrentProtocol(v int) *PreferencesPatch {
+	return p.set("bittorrent_protocol", v)
+}
+
+// SetBlockPeersOnPrivilegedPorts sets the block_peers_on_privileged_ports preference (AppPreferences.BlockPeersOnPrivilegedPorts).
+func (p *PreferencesPatch) SetBlockPeersOnPrivilegedPorts(v bool) *PreferencesPatch {
+	return p.set("block_peers_on_privileged_ports", v)
+}
+
+// SetBypassAuthSubnetWhitelist sets the bypass_auth_subnet_whitelist preference (AppPreferences.BypassAuthSubnetWhitelist).
+func (p *PreferencesPatch) SetBypassAuthSubnetWhitelist(v string) *PreferencesPatch {
+	return p.set("bypass_auth_subnet_whitelist", v)
+}
+
+// SetBypassAuthSubnetWhitelistEnabled sets the bypass_auth_subnet_whitelist_enabled preference (AppPreferences.BypassAuthSubnetWhitelistEnabled).
+func (p *PreferencesPatch) SetBypassAuthSubnetWhitelistEnabled(v bool) *PreferencesPatch {
+	return p.set("bypass_auth_subnet_whitelist_enabled", v)
+}
+
+// SetBypassLocalAuth sets the bypass_local_auth preference (AppPreferences.BypassLocalAuth).
+func (p *PreferencesPatch) SetBypassLocalAuth(v bool) *PreferencesPatch {
+	return p.set("bypass_local_auth", v)
+}
+
+// SetCategoryChangedTmmEnabled sets the category_changed_tmm_enabled preference (AppPreferences.CategoryChangedTmmEnabled).
+func (p *PreferencesPatch) SetCategoryChangedTmmEnabled(v bool) *PreferencesPatch {
+	return p.set("category_changed_tmm_enabled", v)
+}
+
+// SetCheckingMemoryUse sets the checking_memory_use preference (AppPreferences.CheckingMemoryUse).
+func (p *PreferencesPatch) SetCheckingMemoryUse(v int) *PreferencesPatch {
+	return p.set("checking_memory_use", v)
+}
+
+// SetConnectionSpeed sets the connection_speed preference (AppPreferences.ConnectionSpeed).
+func (p *PreferencesPatch) SetConnectionSpeed(v int) *PreferencesPatch {
+	return p.set("connection_speed", v)
+}
+
+// SetCurrentInterfaceAddress sets the current_interface_address preference (AppPreferences.CurrentInterfaceAddress).
+func (p *PreferencesPatch) SetCurrentInterfaceAddress(v string) *PreferencesPatch {
+	return p.set("current_interface_address", v)
+}
+
+// SetCurrentNetworkInterface sets the current_network_interface preference (AppPreferences.CurrentNetworkInterface).
+func (p *PreferencesPatch) SetCurrentNetworkInterface(v string) *PreferencesPatch {
+	return p.set("current_network_interface", v)
+}
+
+// SetDht sets the dht preference (AppPreferences.Dht).
+func (p *PreferencesPatch) SetDht(v bool) *PreferencesPatch {
+	return p.set("dht", v)
+}
+
+// SetDiskCache sets the disk_cache preference (AppPreferences.DiskCache).
+func (p *PreferencesPatch) SetDiskCache(v int) *PreferencesPatch {
+	return p.set("disk_cache", v)
+}
+
+// SetDiskCacheTTL sets the disk_cache_ttl preference (AppPreferences.DiskCacheTTL).
+func (p *PreferencesPatch) SetDiskCacheTTL(v int) *PreferencesPatch {
+	return p.set("disk_cache_ttl", v)
+}
+
+// SetDiskIoReadMode sets the disk_io_read_mode preference (AppPreferences.DiskIoReadMode).
+func (p *PreferencesPatch) SetDiskIoReadMode(v int) *PreferencesPatch {
+	return p.set("disk_io_read_mode", v)
+}
+
+// SetDiskIoType sets the disk_io_type preference (AppPreferences.DiskIoType).
+func (p *PreferencesPatch) SetDiskIoType(v int) *PreferencesPatch {
+	return p.set("disk_io_type", v)
+}
+
+// SetDiskIoWriteMode sets the disk_io_write_mode preference (AppPreferences.DiskIoWriteMode).
+func (p *PreferencesPatch) SetDiskIoWriteMode(v int) *PreferencesPatch {
+	return p.set("disk_io_write_mode", v)
+}
+
+// SetDiskQueueSize sets the disk_queue_size preference (AppPreferences.DiskQueueSize).
+func (p *PreferencesPatch) SetDiskQueueSize(v int) *PreferencesPatch {
+	return p.set("disk_queue_size", v)
+}
+
+// SetDlLimit sets the dl_limit preference (AppPreferences.DlLimit).
+func (p *PreferencesPatch) SetDlLimit(v int) *PreferencesPatch {
+	return p.set("dl_limit", v)
+}
+
+// SetDontCountSlowTorrents sets the dont_count_slow_torrents preference (AppPreferences.DontCountSlowTorrents).
+func (p *PreferencesPatch) SetDontCountSlowTorrents(v bool) *PreferencesPatch {
+	return p.set("dont_count_slow_torrents", v)
+}
+
+// SetDyndnsDomain sets the dyndns_domain preference (AppPreferences.DyndnsDomain).
+func (p *PreferencesPatch) SetDyndnsDomain(v string) *PreferencesPatch {
+	return p.set("dyndns_domain", v)
+}
+
+// SetDyndnsEnabled sets the dyndns_enabled preference (AppPreferences.DyndnsEnabled).
+func (p *PreferencesPatch) SetDyndnsEnabled(v bool) *PreferencesPatch {
+	return p.set("dyndns_enabled", v)
+}
+
+// SetDyndnsPassword sets the dyndns_password preference (AppPreferences.DyndnsPassword).
+func (p *PreferencesPatch) SetDyndnsPassword(v string) *PreferencesPatch {
+	return p.set("dyndns_password", v)
+}
+
+// SetDyndnsService sets the dyndns_service preference (AppPreferences.DyndnsService).
+func (p *PreferencesPatch) SetDyndnsService(v int) *PreferencesPatch {
+	return p.set("dyndns_service", v)
+}
+
+// SetDyndnsUsername sets the dyndns_username preference (AppPreferences.DyndnsUsername).
+func (p *PreferencesPatch) SetDyndnsUsername(v string) *PreferencesPatch {
+	return p.set("dyndns_username", v)
+}
+
+// SetEmbeddedTrackerPort sets the embedded_tracker_port preference (AppPreferences.EmbeddedTrackerPort).
+func (p *PreferencesPatch) SetEmbeddedTrackerPort(v int) *PreferencesPatch {
+	return p.set("embedded_tracker_port", v)
+}
+
+// SetEmbeddedTrackerPortForwarding sets the embedded_tracker_port_forwarding preference (AppPreferences.EmbeddedTrackerPortForwarding).
+func (p *PreferencesPatch) SetEmbeddedTrackerPortForwarding(v bool) *PreferencesPatch {
+	return p.set("embedded_tracker_port_forwarding", v)
+}
+
+// SetEnableCoalesceReadWrite sets the enable_coalesce_read_write preference (AppPreferences.EnableCoalesceReadWrite).
+func (p *PreferencesPatch) SetEnableCoalesceReadWrite(v bool) *PreferencesPatch {
+	return p.set("enable_coalesce_read_write", v)
+}
+
+// SetEnableEmbeddedTracker sets the enable_embedded_tracker preference (AppPreferences.EnableEmbeddedTracker).
+func (p *PreferencesPatch) SetEnableEmbeddedTracker(v bool) *PreferencesPatch {
+	return p.set("enable_embedded_tracker", v)
+}
+
+// SetEnableMultiConnectionsFromSameIP sets the enable_multi_connections_from_same_ip preference (AppPreferences.EnableMultiConnectionsFromSameIP).
+func (p *PreferencesPatch) SetEnableMultiConnectionsFromSameIP(v bool) *PreferencesPatch {
+	return p.set("enable_multi_connections_from_same_ip", v)
+}
+
+// SetEnablePieceExtentAffinity sets the enable_piece_extent_affinity preference (AppPreferences.EnablePieceExtentAffinity).
+func (p *PreferencesPatch) SetEnablePieceExtentAffinity(v bool) *PreferencesPatch {
+	return p.set("enable_piece_extent_affinity", v)
+}
+
+// SetEnableUploadSuggestions sets the enable_upload_suggestions preference (AppPreferences.EnableUploadSuggestions).
+func (p *PreferencesPatch) SetEnableUploadSuggestions(v bool) *PreferencesPatch {
+	return p.set("enable_upload_suggestions", v)
+}
+
+// SetEncryption sets the encryption preference (AppPreferences.Encryption).
+func (p *PreferencesPatch) SetEncryption(v int) *PreferencesPatch {
+	return p.set("encryption", v)
+}
+
+// SetExcludedFileNames sets the excluded_file_names preference (AppPreferences.ExcludedFileNames).
+func (p *PreferencesPatch) SetExcludedFileNames(v string) *PreferencesPatch {
+	return p.set("excluded_file_names", v)
+}
+
+// SetExcludedFileNamesEnabled sets the excluded_file_names_enabled preference (AppPreferences.ExcludedFileNamesEnabled).
+func (p *PreferencesPatch) SetExcludedFileNamesEnabled(v bool) *PreferencesPatch {
+	return p.set("excluded_file_names_enabled", v)
+}
+
+// SetExportDir sets the export_dir preference (AppPreferences.ExportDir).
+func (p *PreferencesPatch) SetExportDir(v string) *PreferencesPatch {
+	return p.set("export_dir", v)
+}
+
+// SetExportDirFin sets the export_dir_fin preference (AppPreferences.ExportDirFin).
+func (p *PreferencesPatch) SetExportDirFin(v string) *PreferencesPatch {
+	return p.set("export_dir_fin", v)
+}
+
+// SetFilePoolSize sets the file_pool_size preference (AppPreferences.FilePoolSize).
+func (p *PreferencesPatch) SetFilePoolSize(v int) *PreferencesPatch {
+	return p.set("file_pool_size", v)
+}
+
+// SetHashingThreads sets the hashing_threads preference (AppPreferences.HashingThreads).
+func (p *PreferencesPatch) SetHashingThreads(v int) *PreferencesPatch {
+	return p.set("hashing_threads", v)
+}
+
+// SetIdnSupportEnabled sets the idn_support_enabled preference (AppPreferences.IdnSupportEnabled).
+func (p *PreferencesPatch) SetIdnSupportEnabled(v bool) *PreferencesPatch {
+	return p.set("idn_support_enabled", v)
+}
+
+// SetIncompleteFilesExt sets the incomplete_files_ext preference (AppPreferences.IncompleteFilesExt).
+func (p *PreferencesPatch) SetIncompleteFilesExt(v bool) *PreferencesPatch {
+	return p.set("incomplete_files_ext", v)
+}
+
+// SetIPFilterEnabled sets the ip_filter_enabled preference (AppPreferences.IPFilterEnabled).
+func (p *PreferencesPatch) SetIPFilterEnabled(v bool) *PreferencesPatch {
+	return p.set("ip_filter_enabled", v)
+}
+
+// SetIPFilterPath sets the ip_filter_path preference (AppPreferences.IPFilterPath).
+func (p *PreferencesPatch) SetIPFilterPath(v string) *PreferencesPatch {
+	return p.set("ip_filter_path", v)
+}
+
+// SetIPFilterTrackers sets the ip_filter_trackers preference (AppPreferences.IPFilterTrackers).
+func (p *PreferencesPatch) SetIPFilterTrackers(v bool) *PreferencesPatch {
+	return p.set("ip_filter_trackers", v)
+}
+
+// SetLimitLanPeers sets the limit_lan_peers preference (AppPreferences.LimitLanPeers).
+func (p *PreferencesPatch) SetLimitLanPeers(v bool) *PreferencesPatch {
+	return p.set("limit_lan_peers", v)
+}
+
+// SetLimitTCPOverhead sets the limit_tcp_overhead preference (AppPreferences.LimitTCPOverhead).
+func (p *PreferencesPatch) SetLimitTCPOverhead(v bool) *PreferencesPatch {
+	return p.set("limit_tcp_overhead", v)
+}
+
+// SetLimitUtpRate sets the limit_utp_rate preference (AppPreferences.LimitUtpRate).
+func (p *PreferencesPatch) SetLimitUtpRate(v bool) *PreferencesPatch {
+	return p.set("limit_utp_rate", v)
+}
+
+// SetListenPort sets the listen_port preference (AppPreferences.ListenPort).
+func (p *PreferencesPatch) SetListenPort(v int) *PreferencesPatch {
+	return p.set("listen_port", v)
+}
+
+// SetLocale sets the locale preference (AppPreferences.Locale).
+func (p *PreferencesPatch) SetLocale(v string) *PreferencesPatch {
+	return p.set("locale", v)
+}
+
+// SetLsd sets the lsd preference (AppPreferences.Lsd).
+func (p *PreferencesPatch) SetLsd(v bool) *PreferencesPatch {
+	return p.set("lsd", v)
+}
+
+// SetMailNotificationAuthEnabled sets the mail_notification_auth_enabled preference (AppPreferences.MailNotificationAuthEnabled).
+func (p *PreferencesPatch) SetMailNotificationAuthEnabled(v bool) *PreferencesPatch {
+	return p.set("mail_notification_auth_enabled", v)
+}
+
+// SetMailNotificationEmail sets the mail_notification_email preference (AppPreferences.MailNotificationEmail).
+func (p *PreferencesPatch) SetMailNotificationEmail(v string) *PreferencesPatch {
+	return p.set("mail_notification_email", v)
+}
+
+// SetMailNotificationEnabled sets the mail_notification_enabled preference (AppPreferences.MailNotificationEnabled).
+func (p *PreferencesPatch) SetMailNotificationEnabled(v bool) *PreferencesPatch {
+	return p.set("mail_notification_enabled", v)
+}
+
+// SetMailNotificationPassword sets the mail_notification_password preference (AppPreferences.MailNotificationPassword).
+func (p *PreferencesPatch) SetMailNotificationPassword(v string) *PreferencesPatch {
+	return p.set("mail_notification_password", v)
+}
+
+// SetMailNotificationSender sets the mail_notification_sender preference (AppPreferences.MailNotificationSender).
+func (p *PreferencesPatch) SetMailNotificationSender(v string) *PreferencesPatch {
+	return p.set("mail_notification_sender", v)
+}
+
+// SetMailNotificationSMTP sets the mail_notification_smtp preference (AppPreferences.MailNotificationSMTP).
+func (p *PreferencesPatch) SetMailNotificationSMTP(v string) *PreferencesPatch {
+	return p.set("mail_notification_smtp", v)
+}
+
+// SetMailNotificationSslEnabled sets the mail_notification_ssl_enabled preference (AppPreferences.MailNotificationSslEnabled).
+func (p *PreferencesPatch) SetMailNotificationSslEnabled(v bool) *PreferencesPatch {
+	return p.set("mail_notification_ssl_enabled", v)
+}
+
+// SetMailNotificationUsername sets the mail_notification_username preference (AppPreferences.MailNotificationUsername).
+func (p *PreferencesPatch) SetMailNotificationUsername(v string) *PreferencesPatch {
+	return p.set("mail_notification_username", v)
+}
+
+// SetMaxActiveCheckingTorrents sets the max_active_checking_torrents preference (AppPreferences.MaxActiveCheckingTorrents).
+func (p *PreferencesPatch) SetMaxActiveCheckingTorrents(v int) *PreferencesPatch {
+	return p.set("max_active_checking_torrents", v)
+}
+
+// SetMaxActiveDownloads sets the max_active_downloads preference (AppPreferences.MaxActiveDownloads).
+func (p *PreferencesPatch) SetMaxActiveDownloads(v int) *PreferencesPatch {
+	return p.set("max_active_downloads", v)
+}
+
+// SetMaxActiveTorrents sets the max_active_torrents preference (AppPreferences.MaxActiveTorrents).
+func (p *PreferencesPatch) SetMaxActiveTorrents(v int) *PreferencesPatch {
+	return p.set("max_active_torrents", v)
+}
+
+// SetMaxActiveUploads sets the max_active_uploads preference (AppPreferences.MaxActiveUploads).
+func (p *PreferencesPatch) SetMaxActiveUploads(v int) *PreferencesPatch {
+	return p.set("max_active_uploads", v)
+}
+
+// SetMaxConcurrentHTTPAnnounces sets the max_concurrent_http_announces preference (AppPreferences.MaxConcurrentHTTPAnnounces).
+func (p *PreferencesPatch) SetMaxConcurrentHTTPAnnounces(v int) *PreferencesPatch {
+	return p.set("max_concurrent_http_announces", v)
+}
+
+// SetMaxConnec sets the max_connec preference (AppPreferences.MaxConnec).
+func (p *PreferencesPatch) SetMaxConnec(v int) *PreferencesPatch {
+	return p.set("max_connec", v)
+}
+
+// SetMaxConnecPerTorrent sets the max_connec_per_torrent preference (AppPreferences.MaxConnecPerTorrent).
+func (p *PreferencesPatch) SetMaxConnecPerTorrent(v int) *PreferencesPatch {
+	return p.set("max_connec_per_torrent", v)
+}
+
+// SetMaxRatio sets the max_ratio preference (AppPreferences.MaxRatio).
+func (p *PreferencesPatch) SetMaxRatio(v float64) *PreferencesPatch {
+	return p.set("max_ratio", v)
+}
+
+// SetMaxRatioAct sets the max_ratio_act preference (AppPreferences.MaxRatioAct).
+func (p *PreferencesPatch) SetMaxRatioAct(v int) *PreferencesPatch {
+	return p.set("max_ratio_act", v)
+}
+
+// SetMaxRatioEnabled sets the max_ratio_enabled preference (AppPreferences.MaxRatioEnabled).
+func (p *PreferencesPatch) SetMaxRatioEnabled(v bool) *PreferencesPatch {
+	return p.set("max_ratio_enabled", v)
+}
+
+// SetMaxSeedingTime sets the max_seeding_time preference (AppPreferences.MaxSeedingTime).
+func (p *PreferencesPatch) SetMaxSeedingTime(v int) *PreferencesPatch {
+	return p.set("max_seeding_time", v)
+}
+
+// SetMaxSeedingTimeEnabled sets the max_seeding_time_enabled preference (AppPreferences.MaxSeedingTimeEnabled).
+func (p *PreferencesPatch) SetMaxSeedingTimeEnabled(v bool) *PreferencesPatch {
+	return p.set("max_seeding_time_enabled", v)
+}
+
+// SetMaxUploads sets the max_uploads preference (AppPreferences.MaxUploads).
+func (p *PreferencesPatch) SetMaxUploads(v int) *PreferencesPatch {
+	return p.set("max_uploads", v)
+}
+
+// SetMaxUploadsPerTorrent sets the max_uploads_per_torrent preference (AppPreferences.MaxUploadsPerTorrent).
+func (p *PreferencesPatch) SetMaxUploadsPerTorrent(v int) *PreferencesPatch {
+	return p.set("max_uploads_per_torrent", v)
+}
+
+// SetMemoryWorkingSetLimit sets the memory_working_set_limit preference (AppPreferences.MemoryWorkingSetLimit).
+func (p *PreferencesPatch) SetMemoryWorkingSetLimit(v int) *PreferencesPatch {
+	return p.set("memory_working_set_limit", v)
+}
+
+// SetOutgoingPortsMax sets the outgoing_ports_max preference (AppPreferences.OutgoingPortsMax).
+func (p *PreferencesPatch) SetOutgoingPortsMax(v int) *PreferencesPatch {
+	return p.set("outgoing_ports_max", v)
+}
+
+// SetOutgoingPortsMin sets the outgoing_ports_min preference (AppPreferences.OutgoingPortsMin).
+func (p *PreferencesPatch) SetOutgoingPortsMin(v int) *PreferencesPatch {
+	return p.set("outgoing_ports_min", v)
+}
+
+// SetPeerTos sets the peer_tos preference (AppPreferences.PeerTos).
+func (p *PreferencesPatch) SetPeerTos(v int) *PreferencesPatch {
+	return p.set("peer_tos", v)
+}
+
+// SetPeerTurnover sets the peer_turnover preference (AppPreferences.PeerTurnover).
+func (p *PreferencesPatch) SetPeerTurnover(v int) *PreferencesPatch {
+	return p.set("peer_turnover", v)
+}
+
+// SetPeerTurnoverCutoff sets the peer_turnover_cutoff preference (AppPreferences.PeerTurnoverCutoff).
+func (p *PreferencesPatch) SetPeerTurnoverCutoff(v int) *PreferencesPatch {
+	return p.set("peer_turnover_cutoff", v)
+}
+
+// SetPeerTurnoverInterval sets the peer_turnover_interval preference (AppPreferences.PeerTurnoverInterval).
+func (p *PreferencesPatch) SetPeerTurnoverInterval(v int) *PreferencesPatch {
+	return p.set("peer_turnover_interval", v)
+}
+
+// SetPerformanceWarning sets the performance_warning preference (AppPreferences.PerformanceWarning).
+func (p *PreferencesPatch) SetPerformanceWarning(v bool) *PreferencesPatch {
+	return p.set("performance_warning", v)
+}
+
+// SetPex sets the pex preference (AppPreferences.Pex).
+func (p *PreferencesPatch) SetPex(v bool) *PreferencesPatch {
+	return p.set("pex", v)
+}
+
+// SetPreallocateAll sets the preallocate_all preference (AppPreferences.PreallocateAll).
+func (p *PreferencesPatch) SetPreallocateAll(v bool) *PreferencesPatch {
+	return p.set("preallocate_all", v)
+}
+
+// SetProxyAuthEnabled sets the proxy_auth_enabled preference (AppPreferences.ProxyAuthEnabled).
+func (p *PreferencesPatch) SetProxyAuthEnabled(v bool) *PreferencesPatch {
+	return p.set("proxy_auth_enabled", v)
+}
+
+// SetProxyHostnameLookup sets the proxy_hostname_lookup preference (AppPreferences.ProxyHostnameLookup).
+func (p *PreferencesPatch) SetProxyHostnameLookup(v bool) *PreferencesPatch {
+	return p.set("proxy_hostname_lookup", v)
+}
+
+// SetProxyIP sets the proxy_ip preference (AppPreferences.ProxyIP).
+func (p *PreferencesPatch) SetProxyIP(v string) *PreferencesPatch {
+	return p.set("proxy_ip", v)
+}
+
+// SetProxyPassword sets the proxy_password preference (AppPreferences.ProxyPassword).
+func (p *PreferencesPatch) SetProxyPassword(v string) *PreferencesPatch {
+	return p.set("proxy_password", v)
+}
+
+// SetProxyPeerConnections sets the proxy_peer_connections preference (AppPreferences.ProxyPeerConnections).
+func (p *PreferencesPatch) SetProxyPeerConnections(v bool) *PreferencesPatch {
+	return p.set("proxy_peer_connections", v)
+}
+
+// SetProxyPort sets the proxy_port preference (AppPreferences.ProxyPort).
+func (p *PreferencesPatch) SetProxyPort(v int) *PreferencesPatch {
+	return p.set("proxy_port", v)
+}
+
+// SetProxyTorrentsOnly sets the proxy_torrents_only preference (AppPreferences.ProxyTorrentsOnly).
+func (p *PreferencesPatch) SetProxyTorrentsOnly(v bool) *PreferencesPatch {
+	return p.set("proxy_torrents_only", v)
+}
+
+// SetProxyType sets the proxy_type preference (AppPreferences.ProxyType).
+func (p *PreferencesPatch) SetProxyType(v interface{}) *PreferencesPatch {
+	return p.set("proxy_type", v)
+}
+
+// SetProxyUsername sets the proxy_username preference (AppPreferences.ProxyUsername).
+func (p *PreferencesPatch) SetProxyUsername(v string) *PreferencesPatch {
+	return p.set("proxy_username", v)
+}
+
+// SetQueueingEnabled sets the queueing_enabled preference (AppPreferences.QueueingEnabled).
+func (p *PreferencesPatch) SetQueueingEnabled(v bool) *PreferencesPatch {
+	return p.set("queueing_enabled", v)
+}
+
+// SetRandomPort sets the random_port preference (AppPreferences.RandomPort).
+func (p *PreferencesPatch) SetRandomPort(v bool) *PreferencesPatch {
+	return p.set("random_port", v)
+}
+
+// SetReannounceWhenAddressChanged sets the reannounce_when_address_changed preference (AppPreferences.ReannounceWhenAddressChanged).
+func (p *PreferencesPatch) SetReannounceWhenAddressChanged(v bool) *PreferencesPatch {
+	return p.set("reannounce_when_address_changed", v)
+}
+
+// SetRecheckCompletedTorrents sets the recheck_completed_torrents preference (AppPreferences.RecheckCompletedTorrents).
+func (p *PreferencesPatch) SetRecheckCompletedTorrents(v bool) *PreferencesPatch {
+	return p.set("recheck_completed_torrents", v)
+}
+
+// SetRefreshInterval sets the refresh_interval preference (AppPreferences.RefreshInterval).
+func (p *PreferencesPatch) SetRefreshInterval(v int) *PreferencesPatch {
+	return p.set("refresh_interval", v)
+}
+
+// SetRequestQueueSize sets the request_queue_size preference (AppPreferences.RequestQueueSize).
+func (p *PreferencesPatch) SetRequestQueueSize(v int) *PreferencesPatch {
+	return p.set("request_queue_size", v)
+}
+
+// SetResolvePeerCountries sets the resolve_peer_countries preference (AppPreferences.ResolvePeerCountries).
+func (p *PreferencesPatch) SetResolvePeerCountries(v bool) *PreferencesPatch {
+	return p.set("resolve_peer_countries", v)
+}
+
+// SetResumeDataStorageType sets the resume_data_storage_type preference (AppPreferences.ResumeDataStorageType).
+func (p *PreferencesPatch) SetResumeDataStorageType(v string) *PreferencesPatch {
+	return p.set("resume_data_storage_type", v)
+}
+
+// SetRssAutoDownloadingEnabled sets the rss_auto_downloading_enabled preference (AppPreferences.RssAutoDownloadingEnabled).
+func (p *PreferencesPatch) SetRssAutoDownloadingEnabled(v bool) *PreferencesPatch {
+	return p.set("rss_auto_downloading_enabled", v)
+}
+
+// SetRssDownloadRepackProperEpisodes sets the rss_download_repack_proper_episodes preference (AppPreferences.RssDownloadRepackProperEpisodes).
+func (p *PreferencesPatch) SetRssDownloadRepackProperEpisodes(v bool) *PreferencesPatch {
+	return p.set("rss_download_repack_proper_episodes", v)
+}
+
+// SetRssMaxArticlesPerFeed sets the rss_max_articles_per_feed preference (AppPreferences.RssMaxArticlesPerFeed).
+func (p *PreferencesPatch) SetRssMaxArticlesPerFeed(v int) *PreferencesPatch {
+	return p.set("rss_max_articles_per_feed", v)
+}
+
+// SetRssProcessingEnabled sets the rss_processing_enabled preference (AppPreferences.RssProcessingEnabled).
+func (p *PreferencesPatch) SetRssProcessingEnabled(v bool) *PreferencesPatch {
+	return p.set("rss_processing_enabled", v)
+}
+
+// SetRssRefreshInterval sets the rss_refresh_interval preference (AppPreferences.RssRefreshInterval).
+func (p *PreferencesPatch) SetRssRefreshInterval(v int) *PreferencesPatch {
+	return p.set("rss_refresh_interval", v)
+}
+
+// SetRssSmartEpisodeFilters sets the rss_smart_episode_filters preference (AppPreferences.RssSmartEpisodeFilters).
+func (p *PreferencesPatch) SetRssSmartEpisodeFilters(v string) *PreferencesPatch {
+	return p.set("rss_smart_episode_filters", v)
+}
+
+// SetSavePath sets the save_path preference (AppPreferences.SavePath).
+func (p *PreferencesPatch) SetSavePath(v string) *PreferencesPatch {
+	return p.set("save_path", v)
+}
+
+// SetSavePathChangedTmmEnabled sets the save_path_changed_tmm_enabled preference (AppPreferences.SavePathChangedTmmEnabled).
+func (p *PreferencesPatch) SetSavePathChangedTmmEnabled(v bool) *PreferencesPatch {
+	return p.set("save_path_changed_tmm_enabled", v)
+}
+
+// SetSaveResumeDataInterval sets the save_resume_data_interval preference (AppPreferences.SaveResumeDataInterval).
+func (p *PreferencesPatch) SetSaveResumeDataInterval(v int) *PreferencesPatch {
+	return p.set("save_resume_data_interval", v)
+}
+
+// SetScanDirs sets the scan_dirs preference (AppPreferences.ScanDirs).
+func (p *PreferencesPatch) SetScanDirs(v MonitoredFolders) *PreferencesPatch {
+	return p.set("scan_dirs", v)
+}
+
+// SetScheduleFromHour sets the schedule_from_hour preference (AppPreferences.ScheduleFromHour).
+func (p *PreferencesPatch) SetScheduleFromHour(v int) *PreferencesPatch {
+	return p.set("schedule_from_hour", v)
+}
+
+// SetScheduleFromMin sets the schedule_from_min preference (AppPreferences.ScheduleFromMin).
+func (p *PreferencesPatch) SetScheduleFromMin(v int) *PreferencesPatch {
+	return p.set("schedule_from_min", v)
+}
+
+// SetScheduleToHour sets the schedule_to_hour preference (AppPreferences.ScheduleToHour).
+func (p *PreferencesPatch) SetScheduleToHour(v int) *PreferencesPatch {
+	return p.set("schedule_to_hour", v)
+}
+
+// SetScheduleToMin sets the schedule_to_min preference (AppPreferences.ScheduleToMin).
+func (p *PreferencesPatch) SetScheduleToMin(v int) *PreferencesPatch {
+	return p.set("schedule_to_min", v)
+}
+
+// SetSchedulerDays sets the scheduler_days preference (AppPreferences.SchedulerDays).
+func (p *PreferencesPatch) SetSchedulerDays(v int) *PreferencesPatch {
+	return p.set("scheduler_days", v)
+}
+
+// SetSchedulerEnabled sets the scheduler_enabled preference (AppPreferences.SchedulerEnabled).
+func (p *PreferencesPatch) SetSchedulerEnabled(v bool) *PreferencesPatch {
+	return p.set("scheduler_enabled", v)
+}
+
+// SetSendBufferLowWatermark sets the send_buffer_low_watermark preference (AppPreferences.SendBufferLowWatermark).
+func (p *PreferencesPatch) SetSendBufferLowWatermark(v int) *PreferencesPatch {
+	return p.set("send_buffer_low_watermark", v)
+}
+
+// SetSendBufferWatermark sets the send_buffer_watermark preference (AppPreferences.SendBufferWatermark).
+func (p *PreferencesPatch) SetSendBufferWatermark(v int) *PreferencesPatch {
+	return p.set("send_buffer_watermark", v)
+}
+
+// SetSendBufferWatermarkFactor sets the send_buffer_watermark_factor preference (AppPreferences.SendBufferWatermarkFactor).
+func (p *PreferencesPatch) SetSendBufferWatermarkFactor(v int) *PreferencesPatch {
+	return p.set("send_buffer_watermark_factor", v)
+}
+
+// SetSlowTorrentDlRateThreshold sets the slow_torrent_dl_rate_threshold preference (AppPreferences.SlowTorrentDlRateThreshold).
+func (p *PreferencesPatch) SetSlowTorrentDlRateThreshold(v int) *PreferencesPatch {
+	return p.set("slow_torrent_dl_rate_threshold", v)
+}
+
+// SetSlowTorrentInactiveTimer sets the slow_torrent_inactive_timer preference (AppPreferences.SlowTorrentInactiveTimer).
+func (p *PreferencesPatch) SetSlowTorrentInactiveTimer(v int) *PreferencesPatch {
+	return p.set("slow_torrent_inactive_timer", v)
+}
+
+// SetSlowTorrentUlRateThreshold sets the slow_torrent_ul_rate_threshold preference (AppPreferences.SlowTorrentUlRateThreshold).
+func (p *PreferencesPatch) SetSlowTorrentUlRateThreshold(v int) *PreferencesPatch {
+	return p.set("slow_torrent_ul_rate_threshold", v)
+}
+
+// SetSocketBacklogSize sets the socket_backlog_size preference (AppPreferences.SocketBacklogSize).
+func (p *PreferencesPatch) SetSocketBacklogSize(v int) *PreferencesPatch {
+	return p.set("socket_backlog_size", v)
+}
+
+// SetSsrfMitigation sets the ssrf_mitigation preference (AppPreferences.SsrfMitigation).
+func (p *PreferencesPatch) SetSsrfMitigation(v bool) *PreferencesPatch {
+	return p.set("ssrf_mitigation", v)
+}
+
+// SetStartPausedEnabled sets the start_paused_enabled preference (AppPreferences.StartPausedEnabled).
+func (p *PreferencesPatch) SetStartPausedEnabled(v bool) *PreferencesPatch {
+	return p.set("start_paused_enabled", v)
+}
+
+// SetStopTrackerTimeout sets the stop_tracker_timeout preference (AppPreferences.StopTrackerTimeout).
+func (p *PreferencesPatch) SetStopTrackerTimeout(v int) *PreferencesPatch {
+	return p.set("stop_tracker_timeout", v)
+}
+
+// SetTempPath sets the temp_path preference (AppPreferences.TempPath).
+func (p *PreferencesPatch) SetTempPath(v string) *PreferencesPatch {
+	return p.set("temp_path", v)
+}
+
+// SetTempPathEnabled sets the temp_path_enabled preference (AppPreferences.TempPathEnabled).
+func (p *PreferencesPatch) SetTempPathEnabled(v bool) *PreferencesPatch {
+	return p.set("temp_path_enabled", v)
+}
+
+// SetTorrentChangedTmmEnabled sets the torrent_changed_tmm_enabled preference (AppPreferences.TorrentChangedTmmEnabled).
+func (p *PreferencesPatch) SetTorrentChangedTmmEnabled(v bool) *PreferencesPatch {
+	return p.set("torrent_changed_tmm_enabled", v)
+}
+
+// SetTorrentContentLayout sets the torrent_content_layout preference (AppPreferences.TorrentContentLayout).
+func (p *PreferencesPatch) SetTorrentContentLayout(v string) *PreferencesPatch {
+	return p.set("torrent_content_layout", v)
+}
+
+// SetTorrentStopCondition sets the torrent_stop_condition preference (AppPreferences.TorrentStopCondition).
+func (p *PreferencesPatch) SetTorrentStopCondition(v string) *PreferencesPatch {
+	return p.set("torrent_stop_condition", v)
+}
+
+// SetUpLimit sets the up_limit preference (AppPreferences.UpLimit).
+func (p *PreferencesPatch) SetUpLimit(v int) *PreferencesPatch {
+	return p.set("up_limit", v)
+}
+
+// SetUploadChokingAlgorithm sets the upload_choking_algorithm preference (AppPreferences.UploadChokingAlgorithm).
+func (p *PreferencesPatch) SetUploadChokingAlgorithm(v int) *PreferencesPatch {
+	return p.set("upload_choking_algorithm", v)
+}
+
+// SetUploadSlotsBehavior sets the upload_slots_behavior preference (AppPreferences.UploadSlotsBehavior).
+func (p *PreferencesPatch) SetUploadSlotsBehavior(v int) *PreferencesPatch {
+	return p.set("upload_slots_behavior", v)
+}
+
+// SetUpnp sets the upnp preference (AppPreferences.Upnp).
+func (p *PreferencesPatch) SetUpnp(v bool) *PreferencesPatch {
+	return p.set("upnp", v)
+}
+
+// SetUpnpLeaseDuration sets the upnp_lease_duration preference (AppPreferences.UpnpLeaseDuration).
+func (p *PreferencesPatch) SetUpnpLeaseDuration(v int) *PreferencesPatch {
+	return p.set("upnp_lease_duration", v)
+}
+
+// SetUseCategoryPathsInManualMode sets the use_category_paths_in_manual_mode preference (AppPreferences.UseCategoryPathsInManualMode).
+func (p *PreferencesPatch) SetUseCategoryPathsInManualMode(v bool) *PreferencesPatch {
+	return p.set("use_category_paths_in_manual_mode", v)
+}
+
+// SetUseHTTPS sets the use_https preference (AppPreferences.UseHTTPS).
+func (p *PreferencesPatch) SetUseHTTPS(v bool) *PreferencesPatch {
+	return p.set("use_https", v)
+}
+
+// SetUseSubcategories sets the use_subcategories preference (AppPreferences.UseSubcategories).
+func (p *PreferencesPatch) SetUseSubcategories(v bool) *PreferencesPatch {
+	return p.set("use_subcategories", v)
+}
+
+// SetUtpTCPMixedMode sets the utp_tcp_mixed_mode preference (AppPreferences.UtpTCPMixedMode).
+func (p *PreferencesPatch) SetUtpTCPMixedMode(v int) *PreferencesPatch {
+	return p.set("utp_tcp_mixed_mode", v)
+}
+
+// SetValidateHTTPSTrackerCertificate sets the validate_https_tracker_certificate preference (AppPreferences.ValidateHTTPSTrackerCertificate).
+func (p *PreferencesPatch) SetValidateHTTPSTrackerCertificate(v bool) *PreferencesPatch {
+	return p.set("validate_https_tracker_certificate", v)
+}
+
+// SetWebUIAddress sets the web_ui_address preference (AppPreferences.WebUIAddress).
+func (p *PreferencesPatch) SetWebUIAddress(v string) *PreferencesPatch {
+	return p.set("web_ui_address", v)
+}
+
+// SetWebUIBanDuration sets the web_ui_ban_duration preference (AppPreferences.WebUIBanDuration).
+func (p *PreferencesPatch) SetWebUIBanDuration(v int) *PreferencesPatch {
+	return p.set("web_ui_ban_duration", v)
+}
+
+// SetWebUIClickjackingProtectionEnabled sets the web_ui_clickjacking_protection_enabled preference (AppPreferences.WebUIClickjackingProtectionEnabled).
+func (p *PreferencesPatch) SetWebUIClickjackingProtectionEnabled(v bool) *PreferencesPatch {
+	return p.set("web_ui_clickjacking_protection_enabled", v)
+}
+
+// SetWebUICsrfProtectionEnabled sets the web_ui_csrf_protection_enabled preference (AppPreferences.WebUICsrfProtectionEnabled).
+func (p *PreferencesPatch) SetWebUICsrfProtectionEnabled(v bool) *PreferencesPatch {
+	return p.set("web_ui_csrf_protection_enabled", v)
+}
+
+// SetWebUICustomHTTPHeaders sets the web_ui_custom_http_headers preference (AppPreferences.WebUICustomHTTPHeaders).
+func (p *PreferencesPatch) SetWebUICustomHTTPHeaders(v string) *PreferencesPatch {
+	return p.set("web_ui_custom_http_headers", v)
+}
+
+// SetWebUIDomainList sets the web_ui_domain_list preference (AppPreferences.WebUIDomainList).
+func (p *PreferencesPatch) SetWebUIDomainList(v string) *PreferencesPatch {
+	return p.set("web_ui_domain_list", v)
+}
+
+// SetWebUIHostHeaderValidationEnabled sets the web_ui_host_header_validation_enabled preference (AppPreferences.WebUIHostHeaderValidationEnabled).
+func (p *PreferencesPatch) SetWebUIHostHeaderValidationEnabled(v bool) *PreferencesPatch {
+	return p.set("web_ui_host_header_validation_enabled", v)
+}
+
+// SetWebUIHTTPSCertPath sets the web_ui_https_cert_path preference (AppPreferences.WebUIHTTPSCertPath).
+func (p *PreferencesPatch) SetWebUIHTTPSCertPath(v string) *PreferencesPatch {
+	return p.set("web_ui_https_cert_path", v)
+}
+
+// SetWebUIHTTPSKeyPath sets the web_ui_https_key_path preference (AppPreferences.WebUIHTTPSKeyPath).
+func (p *PreferencesPatch) SetWebUIHTTPSKeyPath(v string) *PreferencesPatch {
+	return p.set("web_ui_https_key_path", v)
+}
+
+// SetWebUIMaxAuthFailCount sets the web_ui_max_auth_fail_count preference (AppPreferences.WebUIMaxAuthFailCount).
+func (p *PreferencesPatch) SetWebUIMaxAuthFailCount(v int) *PreferencesPatch {
+	return p.set("web_ui_max_auth_fail_count", v)
+}
+
+// SetWebUIPort sets the web_ui_port preference (AppPreferences.WebUIPort).
+func (p *PreferencesPatch) SetWebUIPort(v int) *PreferencesPatch {
+	return p.set("web_ui_port", v)
+}
+
+// SetWebUIReverseProxiesList sets the web_ui_reverse_proxies_list preference (AppPreferences.WebUIReverseProxiesList).
+func (p *PreferencesPatch) SetWebUIReverseProxiesList(v string) *PreferencesPatch {
+	return p.set("web_ui_reverse_proxies_list", v)
+}
+
+// SetWebUIReverseProxyEnabled sets the web_ui_reverse_proxy_enabled preference (AppPreferences.WebUIReverseProxyEnabled).
+func (p *PreferencesPatch) SetWebUIReverseProxyEnabled(v bool) *PreferencesPatch {
+	return p.set("web_ui_reverse_proxy_enabled", v)
+}
+
+// SetWebUISecureCookieEnabled sets the web_ui_secure_cookie_enabled preference (AppPreferences.WebUISecureCookieEnabled).
+func (p *PreferencesPatch) SetWebUISecureCookieEnabled(v bool) *PreferencesPatch {
+	return p.set("web_ui_secure_cookie_enabled", v)
+}
+
+// SetWebUISessionTimeout sets the web_ui_session_timeout preference (AppPreferences.WebUISessionTimeout).
+func (p *PreferencesPatch) SetWebUISessionTimeout(v int) *PreferencesPatch {
+	return p.set("web_ui_session_timeout", v)
+}
+
+// SetWebUIUpnp sets the web_ui_upnp preference (AppPreferences.WebUIUpnp).
+func (p *PreferencesPatch) SetWebUIUpnp(v bool) *PreferencesPatch {
+	return p.set("web_ui_upnp", v)
+}
+
+// SetWebUIUseCustomHTTPHeadersEnabled sets the web_ui_use_custom_http_headers_enabled preference (AppPreferences.WebUIUseCustomHTTPHeadersEnabled).
+func (p *PreferencesPatch) SetWebUIUseCustomHTTPHeadersEnabled(v bool) *PreferencesPatch {
+	return p.set("web_ui_use_custom_http_headers_enabled", v)
+}
+
+// SetWebUIUsername sets the web_ui_username preference (AppPreferences.WebUIUsername).
+func (p *PreferencesPatch) SetWebUIUsername(v string) *PreferencesPatch {
+	return p.set("web_ui_username", v)
+}
+
+// DiffPreferences returns the minimal patch that turns a into b.
+// Only fields whose values differ are included, set to their value in b.
+func DiffPreferences(a, b AppPreferences) *PreferencesPatch {
+	p := NewPreferencesPatch()
+
+	if a.AddTrackers != b.AddTrackers {
+		p.set("add_trackers", b.AddTrackers)
+	}
+	if a.AddTrackersEnabled != b.AddTrackersEnabled {
+		p.set("add_trackers_enabled", b.AddTrackersEnabled)
+	}
+	if a.AltDlLimit != b.AltDlLimit {
+		p.set("alt_dl_limit", b.AltDlLimit)
+	}
+	if a.AltUpLimit != b.AltUpLimit {
+		p.set("alt_up_limit", b.AltUpLimit)
+	}
+	if a.AlternativeWebuiEnabled != b.AlternativeWebuiEnabled {
+		p.set("alternative_webui_enabled", b.AlternativeWebuiEnabled)
+	}
+	if a.AlternativeWebuiPath != b.AlternativeWebuiPath {
+		p.set("alternative_webui_path", b.AlternativeWebuiPath)
+	}
+	if a.AnnounceIP != b.AnnounceIP {
+		p.set("announce_ip", b.AnnounceIP)
+	}
+	if a.AnnounceToAllTiers != b.AnnounceToAllTiers {
+		p.set("announce_to_all_tiers", b.AnnounceToAllTiers)
+	}
+	if a.AnnounceToAllTrackers != b.AnnounceToAllTrackers {
+		p.set("announce_to_all_trackers", b.AnnounceToAllTrackers)
+	}
+	if a.AnonymousMode != b.AnonymousMode {
+		p.set("anonymous_mode", b.AnonymousMode)
+	}
+	if a.AsyncIoThreads != b.AsyncIoThreads {
+		p.set("async_io_threads", b.AsyncIoThreads)
+	}
+	if a.AutoDeleteMode != b.AutoDeleteMode {
+		p.set("auto_delete_mode", b.AutoDeleteMode)
+	}
+	if a.AutoTmmEnabled != b.AutoTmmEnabled {
+		p.set("auto_tmm_enabled", b.AutoTmmEnabled)
+	}
+	if a.AutorunEnabled != b.AutorunEnabled {
+		p.set("autorun_enabled", b.AutorunEnabled)
+	}
+	if a.AutorunOnTorrentAddedEnabled != b.AutorunOnTorrentAddedEnabled {
+		p.set("autorun_on_torrent_added_enabled", b.AutorunOnTorrentAddedEnabled)
+	}
+	if a.AutorunOnTorrentAddedProgram != b.AutorunOnTorrentAddedProgram {
+		p.set("autorun_on_torrent_added_program", b.AutorunOnTorrentAddedProgram)
+	}
+	if a.AutorunProgram != b.AutorunProgram {
+		p.set("autorun_program", b.AutorunProgram)
+	}
+	if a.BannedIPs != b.BannedIPs {
+		p.set("banned_IPs", b.BannedIPs)
+	}
+	if a.BittorrentProtocol != b.BittorrentProtocol {
+		p.set("bittorrent_protocol", b.BittorrentProtocol)
+	}
+	if a.BlockPeersOnPrivilegedPorts != b.BlockPeersOnPrivilegedPorts {
+		p.set("block_peers_on_privileged_ports", b.BlockPeersOnPrivilegedPorts)
+	}
+	if a.BypassAuthSubnetWhitelist != b.BypassAuthSubnetWhitelist {
+		p.set("bypass_auth_subnet_whitelist", b.BypassAuthSubnetWhitelist)
+	}
+	if a.BypassAuthSubnetWhitelistEnabled != b.BypassAuthSubnetWhitelistEnabled {
+		p.set("bypass_auth_subnet_whitelist_enabled", b.BypassAuthSubnetWhitelistEnabled)
+	}
+	if a.BypassLocalAuth != b.BypassLocalAuth {
+		p.set("bypass_local_auth", b.BypassLocalAuth)
+	}
+	if a.CategoryChangedTmmEnabled != b.CategoryChangedTmmEnabled {
+		p.set("category_changed_tmm_enabled", b.CategoryChangedTmmEnabled)
+	}
+	if a.CheckingMemoryUse != b.CheckingMemoryUse {
+		p.set("checking_memory_use", b.CheckingMemoryUse)
+	}
+	if a.ConnectionSpeed != b.ConnectionSpeed {
+		p.set("connection_speed", b.ConnectionSpeed)
+	}
+	if a.CurrentInterfaceAddress != b.CurrentInterfaceAddress {
+		p.set("current_interface_address", b.CurrentInterfaceAddress)
+	}
+	if a.CurrentNetworkInterface != b.CurrentNetworkInterface {
+		p.set("current_network_interface", b.CurrentNetworkInterface)
+	}
+	if a.Dht != b.Dht {
+		p.set("dht", b.Dht)
+	}
+	if a.DiskCache != b.DiskCache {
+		p.set("disk_cache", b.DiskCache)
+	}
+	if a.DiskCacheTTL != b.DiskCacheTTL {
+		p.set("disk_cache_ttl", b.DiskCacheTTL)
+	}
+	if a.DiskIoReadMode != b.DiskIoReadMode {
+		p.set("disk_io_read_mode", b.DiskIoReadMode)
+	}
+	if a.DiskIoType != b.DiskIoType {
+		p.set("disk_io_type", b.DiskIoType)
+	}
+	if a.DiskIoWriteMode != b.DiskIoWriteMode {
+		p.set("disk_io_write_mode", b.DiskIoWriteMode)
+	}
+	if a.DiskQueueSize != b.DiskQueueSize {
+		p.set("disk_queue_size", b.DiskQueueSize)
+	}
+	if a.DlLimit != b.DlLimit {
+		p.set("dl_limit", b.DlLimit)
+	}
+	if a.DontCountSlowTorrents != b.DontCountSlowTorrents {
+		p.set("dont_count_slow_torrents", b.DontCountSlowTorrents)
+	}
+	if a.DyndnsDomain != b.DyndnsDomain {
+		p.set("dyndns_domain", b.DyndnsDomain)
+	}
+	if a.DyndnsEnabled != b.DyndnsEnabled {
+		p.set("dyndns_enabled", b.DyndnsEnabled)
+	}
+	if a.DyndnsPassword != b.DyndnsPassword {
+		p.set("dyndns_password", b.DyndnsPassword)
+	}
+	if a.DyndnsService != b.DyndnsService {
+		p.set("dyndns_service", b.DyndnsService)
+	}
+	if a.DyndnsUsername != b.DyndnsUsername {
+		p.set("dyndns_username", b.DyndnsUsername)
+	}
+	if a.EmbeddedTrackerPort != b.EmbeddedTrackerPort {
+		p.set("embedded_tracker_port", b.EmbeddedTrackerPort)
+	}
+	if a.EmbeddedTrackerPortForwarding != b.EmbeddedTrackerPortForwarding {
+		p.set("embedded_tracker_port_forwarding", b.EmbeddedTrackerPortForwarding)
+	}
+	if a.EnableCoalesceReadWrite != b.EnableCoalesceReadWrite {
+		p.set("enable_coalesce_read_write", b.EnableCoalesceReadWrite)
+	}
+	if a.EnableEmbeddedTracker != b.EnableEmbeddedTracker {
+		p.set("enable_embedded_tracker", b.EnableEmbeddedTracker)
+	}
+	if a.EnableMultiConnectionsFromSameIP != b.EnableMultiConnectionsFromSameIP {
+		p.set("enable_multi_connections_from_same_ip", b.EnableMultiConnectionsFromSameIP)
+	}
+	if a.EnablePieceExtentAffinity != b.EnablePieceExtentAffinity {
+		p.set("enable_piece_extent_affinity", b.EnablePieceExtentAffinity)
+	}
+	if a.EnableUploadSuggestions != b.EnableUploadSuggestions {
+		p.set("enable_upload_suggestions", b.EnableUploadSuggestions)
+	}
+	if a.Encryption != b.Encryption {
+		p.set("encryption", b.Encryption)
+	}
+	if a.ExcludedFileNames != b.ExcludedFileNames {
+		p.set("excluded_file_names", b.ExcludedFileNames)
+	}
+	if a.ExcludedFileNamesEnabled != b.ExcludedFileNamesEnabled {
+		p.set("excluded_file_names_enabled", b.ExcludedFileNamesEnabled)
+	}
+	if a.ExportDir != b.ExportDir {
+		p.set("export_dir", b.ExportDir)
+	}
+	if a.ExportDirFin != b.ExportDirFin {
+		p.set("export_dir_fin", b.ExportDirFin)
+	}
+	if a.FilePoolSize != b.FilePoolSize {
+		p.set("file_pool_size", b.FilePoolSize)
+	}
+	if a.HashingThreads != b.HashingThreads {
+		p.set("hashing_threads", b.HashingThreads)
+	}
+	if a.IdnSupportEnabled != b.IdnSupportEnabled {
+		p.set("idn_support_enabled", b.IdnSupportEnabled)
+	}
+	if a.IncompleteFilesExt != b.IncompleteFilesExt {
+		p.set("incomplete_files_ext", b.IncompleteFilesExt)
+	}
+	if a.IPFilterEnabled != b.IPFilterEnabled {
+		p.set("ip_filter_enabled", b.IPFilterEnabled)
+	}
+	if a.IPFilterPath != b.IPFilterPath {
+		p.set("ip_filter_path", b.IPFilterPath)
+	}
+	if a.IPFilterTrackers != b.IPFilterTrackers {
+		p.set("ip_filter_trackers", b.IPFilterTrackers)
+	}
+	if a.LimitLanPeers != b.LimitLanPeers {
+		p.set("limit_lan_peers", b.LimitLanPeers)
+	}
+	if a.LimitTCPOverhead != b.LimitTCPOverhead {
+		p.set("limit_tcp_overhead", b.LimitTCPOverhead)
+	}
+	if a.LimitUtpRate != b.LimitUtpRate {
+		p.set("limit_utp_rate", b.LimitUtpRate)
+	}
+	if a.ListenPort != b.ListenPort {
+		p.set("listen_port", b.ListenPort)
+	}
+	if a.Locale != b.Locale {
+		p.set("locale", b.Locale)
+	}
+	if a.Lsd != b.Lsd {
+		p.set("lsd", b.Lsd)
+	}
+	if a.MailNotificationAuthEnabled != b.MailNotificationAuthEnabled {
+		p.set("mail_notification_auth_enabled", b.MailNotificationAuthEnabled)
+	}
+	if a.MailNotificationEmail != b.MailNotificationEmail {
+		p.set("mail_notification_email", b.MailNotificationEmail)
+	}
+	if a.MailNotificationEnabled != b.MailNotificationEnabled {
+		p.set("mail_notification_enabled", b.MailNotificationEnabled)
+	}
+	if a.MailNotificationPassword != b.MailNotificationPassword {
+		p.set("mail_notification_password", b.MailNotificationPassword)
+	}
+	if a.MailNotificationSender != b.MailNotificationSender {
+		p.set("mail_notification_sender", b.MailNotificationSender)
+	}
+	if a.MailNotificationSMTP != b.MailNotificationSMTP {
+		p.set("mail_notification_smtp", b.MailNotificationSMTP)
+	}
+	if a.MailNotificationSslEnabled != b.MailNotificationSslEnabled {
+		p.set("mail_notification_ssl_enabled", b.MailNotificationSslEnabled)
+	}
+	if a.MailNotificationUsername != b.MailNotificationUsername {
+		p.set("mail_notification_username", b.MailNotificationUsername)
+	}
+	if a.MaxActiveCheckingTorrents != b.MaxActiveCheckingTorrents {
+		p.set("max_active_checking_torrents", b.MaxActiveCheckingTorrents)
+	}
+	if a.MaxActiveDownloads != b.MaxActiveDownloads {
+		p.set("max_active_downloads", b.MaxActiveDownloads)
+	}
+	if a.MaxActiveTorrents != b.MaxActiveTorrents {
+		p.set("max_active_torrents", b.MaxActiveTorrents)
+	}
+	if a.MaxActiveUploads != b.MaxActiveUploads {
+		p.set("max_active_uploads", b.MaxActiveUploads)
+	}
+	if a.MaxConcurrentHTTPAnnounces != b.MaxConcurrentHTTPAnnounces {
+		p.set("max_concurrent_http_announces", b.MaxConcurrentHTTPAnnounces)
+	}
+	if a.MaxConnec != b.MaxConnec {
+		p.set("max_connec", b.MaxConnec)
+	}
+	if a.MaxConnecPerTorrent != b.MaxConnecPerTorrent {
+		p.set("max_connec_per_torrent", b.MaxConnecPerTorrent)
+	}
+	if a.MaxRatio != b.MaxRatio {
+		p.set("max_ratio", b.MaxRatio)
+	}
+	if a.MaxRatioAct != b.MaxRatioAct {
+		p.set("max_ratio_act", b.MaxRatioAct)
+	}
+	if a.MaxRatioEnabled != b.MaxRatioEnabled {
+		p.set("max_ratio_enabled", b.MaxRatioEnabled)
+	}
+	if a.MaxSeedingTime != b.MaxSeedingTime {
+		p.set("max_seeding_time", b.MaxSeedingTime)
+	}
+	if a.MaxSeedingTimeEnabled != b.MaxSeedingTimeEnabled {
+		p.set("max_seeding_time_enabled", b.MaxSeedingTimeEnabled)
+	}
+	if a.MaxUploads != b.MaxUploads {
+		p.set("max_uploads", b.MaxUploads)
+	}
+	if a.MaxUploadsPerTorrent != b.MaxUploadsPerTorrent {
+		p.set("max_uploads_per_torrent", b.MaxUploadsPerTorrent)
+	}
+	if a.MemoryWorkingSetLimit != b.MemoryWorkingSetLimit {
+		p.set("memory_working_set_limit", b.MemoryWorkingSetLimit)
+	}
+	if a.OutgoingPortsMax != b.OutgoingPortsMax {
+		p.set("outgoing_ports_max", b.OutgoingPortsMax)
+	}
+	if a.OutgoingPortsMin != b.OutgoingPortsMin {
+		p.set("outgoing_ports_min", b.OutgoingPortsMin)
+	}
+	if a.PeerTos != b.PeerTos {
+		p.set("peer_tos", b.PeerTos)
+	}
+	if a.PeerTurnover != b.PeerTurnover {
+		p.set("peer_turnover", b.PeerTurnover)
+	}
+	if a.PeerTurnoverCutoff != b.PeerTurnoverCutoff {
+		p.set("peer_turnover_cutoff", b.PeerTurnoverCutoff)
+	}
+	if a.PeerTurnoverInterval != b.PeerTurnoverInterval {
+		p.set("peer_turnover_interval", b.PeerTurnoverInterval)
+	}
+	if a.PerformanceWarning != b.PerformanceWarning {
+		p.set("performance_warning", b.PerformanceWarning)
+	}
+	if a.Pex != b.Pex {
+		p.set("pex", b.Pex)
+	}
+	if a.PreallocateAll != b.PreallocateAll {
+		p.set("preallocate_all", b.PreallocateAll)
+	}
+	if a.ProxyAuthEnabled != b.ProxyAuthEnabled {
+		p.set("proxy_auth_enabled", b.ProxyAuthEnabled)
+	}
+	if a.ProxyHostnameLookup != b.ProxyHostnameLookup {
+		p.set("proxy_hostname_lookup", b.ProxyHostnameLookup)
+	}
+	if a.ProxyIP != b.ProxyIP {
+		p.set("proxy_ip", b.ProxyIP)
+	}
+	if a.ProxyPassword != b.ProxyPassword {
+		p.set("proxy_password", b.ProxyPassword)
+	}
+	if a.ProxyPeerConnections != b.ProxyPeerConnections {
+		p.set("proxy_peer_connections", b.ProxyPeerConnections)
+	}
+	if a.ProxyPort != b.ProxyPort {
+		p.set("proxy_port", b.ProxyPort)
+	}
+	if a.ProxyTorrentsOnly != b.ProxyTorrentsOnly {
+		p.set("proxy_torrents_only", b.ProxyTorrentsOnly)
+	}
+	if !reflect.DeepEqual(a.ProxyType, b.ProxyType) {
+		p.set("proxy_type", b.ProxyType)
+	}
+	if a.ProxyUsername != b.ProxyUsername {
+		p.set("proxy_username", b.ProxyUsername)
+	}
+	if a.QueueingEnabled != b.QueueingEnabled {
+		p.set("queueing_enabled", b.QueueingEnabled)
+	}
+	if a.RandomPort != b.RandomPort {
+		p.set("random_port", b.RandomPort)
+	}
+	if a.ReannounceWhenAddressChanged != b.ReannounceWhenAddressChanged {
+		p.set("reannounce_when_address_changed", b.ReannounceWhenAddressChanged)
+	}
+	if a.RecheckCompletedTorrents != b.RecheckCompletedTorrents {
+		p.set("recheck_completed_torrents", b.RecheckCompletedTorrents)
+	}
+	if a.RefreshInterval != b.RefreshInterval {
+		p.set("refresh_interval", b.RefreshInterval)
+	}
+	if a.RequestQueueSize != b.RequestQueueSize {
+		p.set("request_queue_size", b.RequestQueueSize)
+	}
+	if a.ResolvePeerCountries != b.ResolvePeerCountries {
+		p.set("resolve_peer_countries", b.ResolvePeerCountries)
+	}
+	if a.ResumeDataStorageType != b.ResumeDataStorageType {
+		p.set("resume_data_storage_type", b.ResumeDataStorageType)
+	}
+	if a.RssAutoDownloadingEnabled != b.RssAutoDownloadingEnabled {
+		p.set("rss_auto_downloading_enabled", b.RssAutoDownloadingEnabled)
+	}
+	if a.RssDownloadRepackProperEpisodes != b.RssDownloadRepackProperEpisodes {
+		p.set("rss_download_repack_proper_episodes", b.RssDownloadRepackProperEpisodes)
+	}
+	if a.RssMaxArticlesPerFeed != b.RssMaxArticlesPerFeed {
+		p.set("rss_max_articles_per_feed", b.RssMaxArticlesPerFeed)
+	}
+	if a.RssProcessingEnabled != b.RssProcessingEnabled {
+		p.set("rss_processing_enabled", b.RssProcessingEnabled)
+	}
+	if a.RssRefreshInterval != b.RssRefreshInterval {
+		p.set("rss_refresh_interval", b.RssRefreshInterval)
+	}
+	if a.RssSmartEpisodeFilters != b.RssSmartEpisodeFilters {
+		p.set("rss_smart_episode_filters", b.RssSmartEpisodeFilters)
+	}
+	if a.SavePath != b.SavePath {
+		p.set("save_path", b.SavePath)
+	}
+	if a.SavePathChangedTmmEnabled != b.SavePathChangedTmmEnabled {
+		p.set("save_path_changed_tmm_enabled", b.SavePathChangedTmmEnabled)
+	}
+	if a.SaveResumeDataInterval != b.SaveResumeDataInterval {
+		p.set("save_resume_data_interval", b.SaveResumeDataInterval)
+	}
+	if !maps.Equal(a.ScanDirs, b.ScanDirs) {
+		p.set("scan_dirs", b.ScanDirs)
+	}
+	if a.ScheduleFromHour != b.ScheduleFromHour {
+		p.set("schedule_from_hour", b.ScheduleFromHour)
+	}
+	if a.ScheduleFromMin != b.ScheduleFromMin {
+		p.set("schedule_from_min", b.ScheduleFromMin)
+	}
+	if a.ScheduleToHour != b.ScheduleToHour {
+		p.set("schedule_to_hour", b.ScheduleToHour)
+	}
+	if a.ScheduleToMin != b.ScheduleToMin {
+		p.set("schedule_to_min", b.ScheduleToMin)
+	}
+	if a.SchedulerDays != b.SchedulerDays {
+		p.set("scheduler_days", b.SchedulerDays)
+	}
+	if a.SchedulerEnabled != b.SchedulerEnabled {
+		p.set("scheduler_enabled", b.SchedulerEnabled)
+	}
+	if a.SendBufferLowWatermark != b.SendBufferLowWatermark {
+		p.set("send_buffer_low_watermark", b.SendBufferLowWatermark)
+	}
+	if a.SendBufferWatermark != b.SendBufferWatermark {
+		p.set("send_buffer_watermark", b.SendBufferWatermark)
+	}
+	if a.SendBufferWatermarkFactor != b.SendBufferWatermarkFactor {
+		p.set("send_buffer_watermark_factor", b.SendBufferWatermarkFactor)
+	}
+	if a.SlowTorrentDlRateThreshold != b.SlowTorrentDlRateThreshold {
+		p.set("slow_torrent_dl_rate_threshold", b.SlowTorrentDlRateThreshold)
+	}
+	if a.SlowTorrentInactiveTimer != b.SlowTorrentInactiveTimer {
+		p.set("slow_torrent_inactive_timer", b.SlowTorrentInactiveTimer)
+	}
+	if a.SlowTorrentUlRateThreshold != b.SlowTorrentUlRateThreshold {
+		p.set("slow_torrent_ul_rate_threshold", b.SlowTorrentUlRateThreshold)
+	}
+	if a.SocketBacklogSize != b.SocketBacklogSize {
+		p.set("socket_backlog_size", b.SocketBacklogSize)
+	}
+	if a.SsrfMitigation != b.SsrfMitigation {
+		p.set("ssrf_mitigation", b.SsrfMitigation)
+	}
+	if a.StartPausedEnabled != b.StartPausedEnabled {
+		p.set("start_paused_enabled", b.StartPausedEnabled)
+	}
+	if a.StopTrackerTimeout != b.StopTrackerTimeout {
+		p.set("stop_tracker_timeout", b.StopTrackerTimeout)
+	}
+	if a.TempPath != b.TempPath {
+		p.set("temp_path", b.TempPath)
+	}
+	if a.TempPathEnabled != b.TempPathEnabled {
+		p.set("temp_path_enabled", b.TempPathEnabled)
+	}
+	if a.TorrentChangedTmmEnabled != b.TorrentChangedTmmEnabled {
+		p.set("torrent_changed_tmm_enabled", b.TorrentChangedTmmEnabled)
+	}
+	if a.TorrentContentLayout != b.TorrentContentLayout {
+		p.set("torrent_content_layout", b.TorrentContentLayout)
+	}
+	if a.TorrentStopCondition != b.TorrentStopCondition {
+		p.set("torrent_stop_condition", b.TorrentStopCondition)
+	}
+	if a.UpLimit != b.UpLimit {
+		p.set("up_limit", b.UpLimit)
+	}
+	if a.UploadChokingAlgorithm != b.UploadChokingAlgorithm {
+		p.set("upload_choking_algorithm", b.UploadChokingAlgorithm)
+	}
+	if a.UploadSlotsBehavior != b.UploadSlotsBehavior {
+		p.set("upload_slots_behavior", b.UploadSlotsBehavior)
+	}
+	if a.Upnp != b.Upnp {
+		p.set("upnp", b.Upnp)
+	}
+	if a.UpnpLeaseDuration != b.UpnpLeaseDuration {
+		p.set("upnp_lease_duration", b.UpnpLeaseDuration)
+	}
+	if a.UseCategoryPathsInManualMode != b.UseCategoryPathsInManualMode {
+		p.set("use_category_paths_in_manual_mode", b.UseCategoryPathsInManualMode)
+	}
+	if a.UseHTTPS != b.UseHTTPS {
+		p.set("use_https", b.UseHTTPS)
+	}
+	if a.UseSubcategories != b.UseSubcategories {
+		p.set("use_subcategories", b.UseSubcategories)
+	}
+	if a.UtpTCPMixedMode != b.UtpTCPMixedMode {
+		p.set("utp_tcp_mixed_mode", b.UtpTCPMixedMode)
+	}
+	if a.ValidateHTTPSTrackerCertificate != b.ValidateHTTPSTrackerCertificate {
+		p.set("validate_https_tracker_certificate", b.ValidateHTTPSTrackerCertificate)
+	}
+	if a.WebUIAddress != b.WebUIAddress {
+		p.set("web_ui_address", b.WebUIAddress)
+	}
+	if a.WebUIBanDuration != b.WebUIBanDuration {
+		p.set("web_ui_ban_duration", b.WebUIBanDuration)
+	}
+	if a.WebUIClickjackingProtectionEnabled != b.WebUIClickjackingProtectionEnabled {
+		p.set("web_ui_clickjacking_protection_enabled", b.WebUIClickjackingProtectionEnabled)
+	}
+	if a.WebUICsrfProtectionEnabled != b.WebUICsrfProtectionEnabled {
+		p.set("web_ui_csrf_protection_enabled", b.WebUICsrfProtectionEnabled)
+	}
+	if a.WebUICustomHTTPHeaders != b.WebUICustomHTTPHeaders {
+		p.set("web_ui_custom_http_headers", b.WebUICustomHTTPHeaders)
+	}
+	if a.WebUIDomainList != b.WebUIDomainList {
+		p.set("web_ui_domain_list", b.WebUIDomainList)
+	}
+	if a.WebUIHostHeaderValidationEnabled != b.WebUIHostHeaderValidationEnabled {
+		p.set("web_ui_host_header_validation_enabled", b.WebUIHostHeaderValidationEnabled)
+	}
+	if a.WebUIHTTPSCertPath != b.WebUIHTTPSCertPath {
+		p.set("web_ui_https_cert_path", b.WebUIHTTPSCertPath)
+	}
+	if a.WebUIHTTPSKeyPath != b.WebUIHTTPSKeyPath {
+		p.set("web_ui_https_key_path", b.WebUIHTTPSKeyPath)
+	}
+	if a.WebUIMaxAuthFailCount != b.WebUIMaxAuthFailCount {
+		p.set("web_ui_max_auth_fail_count", b.WebUIMaxAuthFailCount)
+	}
+	if a.WebUIPort != b.WebUIPort {
+		p.set("web_ui_port", b.WebUIPort)
+	}
+	if a.WebUIReverseProxiesList != b.WebUIReverseProxiesList {
+		p.set("web_ui_reverse_proxies_list", b.WebUIReverseProxiesList)
+	}
+	if a.WebUIReverseProxyEnabled != b.WebUIReverseProxyEnabled {
+		p.set("web_ui_reverse_proxy_enabled", b.WebUIReverseProxyEnabled)
+	}
+	if a.WebUISecureCookieEnabled != b.WebUISecureCookieEnabled {
+		p.set("web_ui_secure_cookie_enabled", b.WebUISecureCookieEnabled)
+	}
+	if a.WebUISessionTimeout != b.WebUISessionTimeout {
+		p.set("web_ui_session_timeout", b.WebUISessionTimeout)
+	}
+	if a.WebUIUpnp != b.WebUIUpnp {
+		p.set("web_ui_upnp", b.WebUIUpnp)
+	}
+	if a.WebUIUseCustomHTTPHeadersEnabled != b.WebUIUseCustomHTTPHeadersEnabled {
+		p.set("web_ui_use_custom_http_headers_enabled", b.WebUIUseCustomHTTPHeadersEnabled)
+	}
+	if a.WebUIUsername != b.WebUIUsername {
+		p.set("web_ui_username", b.WebUIUsername)
+	}
+
+	return p
+}
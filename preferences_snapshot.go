@@ -0,0 +1,200 @@
+package qbittorrent
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/autobrr/go-qbittorrent/errors"
+)
+
+// PreferencesSnapshotFormatVersion is the format version written by SnapshotPreferences.
+const PreferencesSnapshotFormatVersion = 1
+
+// PreferencesSnapshot is a saved copy of the application preferences.
+type PreferencesSnapshot struct {
+	FormatVersion int            `json:"format_version"`
+	WebAPIVersion string         `json:"webapi_version"`
+	CreatedAt     time.Time      `json:"created_at"`
+	Preferences   AppPreferences `json:"preferences"`
+}
+
+// PreferenceDrift describes a single preference that differs from the snapshot.
+type PreferenceDrift struct {
+	Key      string      `json:"key"`
+	Expected interface{} `json:"expected"`
+	Actual   interface{} `json:"actual"`
+}
+
+// PreferencesDriftReport is the result of comparing the live preferences against a snapshot.
+type PreferencesDriftReport struct {
+	WebAPIVersion string            `json:"webapi_version"`
+	Drift         []PreferenceDrift `json:"drift"`
+	// Skipped holds drifted keys that the connected server does not report, because its
+	// version predates them
+	Skipped []string `json:"skipped,omitempty"`
+	// Patch restores the snapshot values for every key in Drift
+	Patch *PreferencesPatch `json:"-"`
+}
+
+// HasDrift reports whether any restorable preference differs from the snapshot.
+func (r *PreferencesDriftReport) HasDrift() bool {
+	return len(r.Drift) > 0
+}
+
+// LoadPreferencesSnapshot reads a snapshot previously written by PreferencesSnapshot.Save.
+func LoadPreferencesSnapshot(r io.Reader) (*PreferencesSnapshot, error) {
+	var snapshot PreferencesSnapshot
+	if err := json.NewDecoder(r).Decode(&snapshot); err != nil {
+		return nil, errors.Wrap(err, "could not decode preferences snapshot")
+	}
+
+	if snapshot.FormatVersion != PreferencesSnapshotFormatVersion {
+		return nil, errors.Wrap(ErrUnsupportedPreferencesSnapshot, "format version: %d", snapshot.FormatVersion)
+	}
+
+	return &snapshot, nil
+}
+
+// Save writes the snapshot as indented JSON.
+func (s *PreferencesSnapshot) Save(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	if err := enc.Encode(s); err != nil {
+		return errors.Wrap(err, "could not encode preferences snapshot")
+	}
+
+	return nil
+}
+
+// SnapshotPreferences captures the current application preferences.
+func (c *Client) SnapshotPreferences() (*PreferencesSnapshot, error) {
+	return c.SnapshotPreferencesCtx(context.Background())
+}
+
+// SnapshotPreferencesCtx captures the current application preferences along with the WebAPI version.
+func (c *Client) SnapshotPreferencesCtx(ctx context.Context) (*PreferencesSnapshot, error) {
+	version, err := c.getApiVersion()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get api version")
+	}
+
+	prefs, err := c.GetAppPreferencesCtx(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not snapshot preferences")
+	}
+
+	return &PreferencesSnapshot{
+		FormatVersion: PreferencesSnapshotFormatVersion,
+		WebAPIVersion: version.String(),
+		CreatedAt:     time.Now().UTC(),
+		Preferences:   prefs,
+	}, nil
+}
+
+// DetectPreferencesDrift compares the live preferences against snapshot.
+func (c *Client) DetectPreferencesDrift(snapshot *PreferencesSnapshot) (*PreferencesDriftReport, error) {
+	return c.DetectPreferencesDriftCtx(context.Background(), snapshot)
+}
+
+// DetectPreferencesDriftCtx compares the live preferences against snapshot field by field.
+// Keys missing from the live preferences, which the connected server does not know, are
+// reported in Skipped instead of Drift.
+func (c *Client) DetectPreferencesDriftCtx(ctx context.Context, snapshot *PreferencesSnapshot) (*PreferencesDriftReport, error) {
+	version, err := c.getApiVersion()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get api version")
+	}
+
+	live, liveKeys, err := c.getAppPreferencesWithKeysCtx(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get live preferences")
+	}
+
+	restore := DiffPreferences(live, snapshot.Preferences)
+	current := DiffPreferences(snapshot.Preferences, live)
+
+	report := &PreferencesDriftReport{
+		WebAPIVersion: version.String(),
+		Patch:         restore,
+	}
+
+	for _, key := range restore.Keys() {
+		if !liveKeys[key] {
+			restore.Delete(key)
+			report.Skipped = append(report.Skipped, key)
+			continue
+		}
+
+		expected, _ := restore.Get(key)
+		actual, _ := current.Get(key)
+
+		report.Drift = append(report.Drift, PreferenceDrift{
+			Key:      key,
+			Expected: expected,
+			Actual:   actual,
+		})
+	}
+
+	return report, nil
+}
+
+// RestorePreferences resets every drifted preference to its snapshot value.
+func (c *Client) RestorePreferences(snapshot *PreferencesSnapshot) (*PreferencesDriftReport, error) {
+	return c.RestorePreferencesCtx(context.Background(), snapshot)
+}
+
+// RestorePreferencesCtx resets every drifted preference to its snapshot value in a single
+// SetPreferencesCtx call and returns the drift that was restored.
+func (c *Client) RestorePreferencesCtx(ctx context.Context, snapshot *PreferencesSnapshot) (*PreferencesDriftReport, error) {
+	report, err := c.DetectPreferencesDriftCtx(ctx, snapshot)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.ApplyPreferencesPatchCtx(ctx, report.Patch); err != nil {
+		return nil, errors.Wrap(err, "could not restore preferences")
+	}
+
+	return report, nil
+}
+
+// getAppPreferencesWithKeysCtx returns the application preferences together with the keys the
+// server sent. Preferences the server does not know decode as zero values, so only the keys tell
+// them apart.
+func (c *Client) getAppPreferencesWithKeysCtx(ctx context.Context) (AppPreferences, map[string]bool, error) {
+	var app AppPreferences
+	resp, err := c.getCtx(ctx, "app/preferences", nil)
+	if err != nil {
+		return app, nil, errors.Wrap(err, "could not get app preferences")
+	}
+
+	defer drainAndClose(resp)
+
+	if resp.StatusCode != http.StatusOK {
+		return app, nil, errors.Wrap(ErrUnexpectedStatus, "could not get app preferences; status code: %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return app, nil, errors.Wrap(err, "could not read body")
+	}
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return app, nil, errors.Wrap(err, "could not unmarshal body")
+	}
+	if err := json.Unmarshal(body, &app); err != nil {
+		return app, nil, errors.Wrap(err, "could not unmarshal body")
+	}
+
+	keys := make(map[string]bool, len(raw))
+	for key := range raw {
+		keys[key] = true
+	}
+
+	return app, keys, nil
+}
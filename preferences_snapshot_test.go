@@ -0,0 +1,120 @@
+package qbittorrent
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/autobrr/go-qbittorrent/errors"
+)
+
+type fakePreferencesServer struct {
+	version string
+	prefs   AppPreferences
+	// missing are keys left out of app/preferences, as by servers older than the preference
+	missing []string
+	posted  []string
+}
+
+func (f *fakePreferencesServer) handler(t *testing.T) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v2/app/webapiVersion", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(f.version))
+	})
+	mux.HandleFunc("/api/v2/app/preferences", func(w http.ResponseWriter, _ *http.Request) {
+		data, err := json.Marshal(f.prefs)
+		require.NoError(t, err)
+		var prefs map[string]any
+		require.NoError(t, json.Unmarshal(data, &prefs))
+		for _, key := range f.missing {
+			delete(prefs, key)
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(prefs))
+	})
+	mux.HandleFunc("/api/v2/app/setPreferences", func(_ http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		f.posted = append(f.posted, r.FormValue("json"))
+	})
+	return mux
+}
+
+func TestPreferencesSnapshot_SaveLoad(t *testing.T) {
+	snapshot := &PreferencesSnapshot{
+		FormatVersion: PreferencesSnapshotFormatVersion,
+		WebAPIVersion: "2.9.3",
+		Preferences:   AppPreferences{MaxActiveDownloads: 4, SavePath: "/data"},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, snapshot.Save(&buf))
+
+	loaded, err := LoadPreferencesSnapshot(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, snapshot.WebAPIVersion, loaded.WebAPIVersion)
+	assert.Equal(t, snapshot.Preferences.MaxActiveDownloads, loaded.Preferences.MaxActiveDownloads)
+	assert.Equal(t, snapshot.Preferences.SavePath, loaded.Preferences.SavePath)
+
+	_, err = LoadPreferencesSnapshot(strings.NewReader(`{"format_version":99}`))
+	assert.True(t, errors.Is(err, ErrUnsupportedPreferencesSnapshot))
+}
+
+func TestClient_PreferencesDriftAndRestore(t *testing.T) {
+	fake := &fakePreferencesServer{
+		version: "2.8.3",
+		prefs:   AppPreferences{MaxActiveDownloads: 3, SavePath: "/data", TorrentContentLayout: "Original"},
+		missing: []string{"torrent_stop_condition", "memory_working_set_limit"},
+	}
+
+	server := httptest.NewServer(fake.handler(t))
+	defer server.Close()
+
+	client := NewClient(Config{Host: server.URL})
+
+	snapshot, err := client.SnapshotPreferences()
+	require.NoError(t, err)
+	assert.Equal(t, "2.8.3", snapshot.WebAPIVersion)
+	assert.Equal(t, PreferencesSnapshotFormatVersion, snapshot.FormatVersion)
+
+	report, err := client.DetectPreferencesDrift(snapshot)
+	require.NoError(t, err)
+	assert.False(t, report.HasDrift())
+
+	// hand-edit the server, including keys this server does not know
+	fake.prefs.MaxActiveDownloads = 10
+	fake.prefs.SavePath = "/tmp"
+	snapshot.Preferences.TorrentStopCondition = "MetadataReceived"
+	snapshot.Preferences.MemoryWorkingSetLimit = 512
+
+	report, err = client.DetectPreferencesDrift(snapshot)
+	require.NoError(t, err)
+	assert.Equal(t, []PreferenceDrift{
+		{Key: "max_active_downloads", Expected: 3, Actual: 10},
+		{Key: "save_path", Expected: "/data", Actual: "/tmp"},
+	}, report.Drift)
+	assert.Equal(t, []string{"memory_working_set_limit", "torrent_stop_condition"}, report.Skipped)
+
+	_, err = client.RestorePreferences(snapshot)
+	require.NoError(t, err)
+	require.Len(t, fake.posted, 1)
+	assert.JSONEq(t, `{"max_active_downloads":3,"save_path":"/data"}`, fake.posted[0])
+}
+
+func TestClient_RestorePreferences_NoDrift(t *testing.T) {
+	fake := &fakePreferencesServer{version: "2.11.2", prefs: AppPreferences{Dht: true}}
+
+	server := httptest.NewServer(fake.handler(t))
+	defer server.Close()
+
+	client := NewClient(Config{Host: server.URL})
+
+	report, err := client.RestorePreferences(&PreferencesSnapshot{Preferences: fake.prefs})
+	require.NoError(t, err)
+	assert.False(t, report.HasDrift())
+	assert.Empty(t, fake.posted)
+}
@@ -0,0 +1,83 @@
+package qbittorrent
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPreferencesPatch_OnlySetFields(t *testing.T) {
+	patch := NewPreferencesPatch().
+		SetMaxActiveDownloads(0).
+		SetQueueingEnabled(false).
+		SetSavePath("/data")
+
+	assert.Equal(t, []string{"max_active_downloads", "queueing_enabled", "save_path"}, patch.Keys())
+
+	data, err := json.Marshal(patch)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"max_active_downloads":0,"queueing_enabled":false,"save_path":"/data"}`, string(data))
+}
+
+func TestDiffPreferences(t *testing.T) {
+	a := AppPreferences{
+		MaxActiveDownloads: 3,
+		QueueingEnabled:    true,
+		SavePath:           "/data",
+		ProxyType:          "None",
+		ScanDirs: MonitoredFolders{
+			"/watch": NewMonitoredFolderTarget(MonitoredFolderModeDefaultSavePath),
+		},
+	}
+
+	t.Run("identical", func(t *testing.T) {
+		assert.True(t, DiffPreferences(a, a).IsEmpty())
+	})
+
+	t.Run("changed fields", func(t *testing.T) {
+		b := a
+		b.MaxActiveDownloads = 5
+		b.QueueingEnabled = false
+		b.ProxyType = "SOCKS5"
+		b.ScanDirs = MonitoredFolders{
+			"/watch": NewMonitoredFolderCustomPath("/data/watch"),
+		}
+
+		patch := DiffPreferences(a, b)
+		assert.Equal(t, []string{"max_active_downloads", "proxy_type", "queueing_enabled", "scan_dirs"}, patch.Keys())
+
+		v, ok := patch.Get("queueing_enabled")
+		require.True(t, ok)
+		assert.Equal(t, false, v)
+
+		v, ok = patch.Get("scan_dirs")
+		require.True(t, ok)
+		assert.Equal(t, b.ScanDirs, v)
+	})
+}
+
+func TestClient_ApplyPreferencesPatch(t *testing.T) {
+	var posted []string
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v2/app/setPreferences", func(_ http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		posted = append(posted, r.FormValue("json"))
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := NewClient(Config{Host: server.URL})
+
+	require.NoError(t, client.ApplyPreferencesPatch(NewPreferencesPatch()))
+	assert.Empty(t, posted, "empty patch should not be sent")
+
+	require.NoError(t, client.ApplyPreferencesPatch(NewPreferencesPatch().SetDht(false)))
+	require.Len(t, posted, 1)
+	assert.JSONEq(t, `{"dht":false}`, posted[0])
+}
@@ -4,9 +4,11 @@ import (
 	"crypto/tls"
 	"io"
 	"log"
+	"log/slog"
 	"net"
 	"net/http"
 	"net/http/cookiejar"
+	"sync"
 	"time"
 
 	"github.com/Masterminds/semver"
@@ -15,6 +17,9 @@ import (
 
 var (
 	DefaultTimeout = 60 * time.Second
+
+	// DefaultMaxRequestSize keeps torrent uploads well below the request size limit of the WebUI
+	DefaultMaxRequestSize int64 = 32 << 20
 )
 
 type Client struct {
@@ -26,7 +31,13 @@ type Client struct {
 	retryAttempts int
 	retryDelay    time.Duration
 
-	log *log.Logger
+	maxRequestSize int64
+
+	middlewareMu sync.RWMutex
+	middleware   []Middleware
+
+	log    *log.Logger
+	logger *slog.Logger
 
 	version *semver.Version
 }
@@ -49,9 +60,32 @@ type Config struct {
 	Timeout int
 	Log     *log.Logger
 
-	// Retry settings
+	// Logger receives structured records about requests, retries and logins, with the attributes
+	// endpoint, method, host, hash, attempt, status and duration where they apply.
+	Logger *slog.Logger
+	// LogBodies adds request parameters and response bodies to debug records, with passwords,
+	// tokens and API keys redacted
+	LogBodies bool
+
+	// Metrics, if set, collects request, retry and sync metrics for this client
+	Metrics *Metrics
+
+	// Retry settings, used by the default BackoffPolicy
 	RetryAttempts int
-	RetryDelay    int // in seconds
+	RetryDelay    int // in seconds, the cap of the first backoff
+
+	// RetryPolicy decides which failed requests are retried and when.
+	// Nil uses a BackoffPolicy built from RetryAttempts and RetryDelay.
+	RetryPolicy RetryPolicy
+	// EndpointRetryPolicies overrides RetryPolicy for individual endpoints, e.g. "torrents/add"
+	EndpointRetryPolicies map[string]RetryPolicy
+	// Clock is the time source for retry delays; nil uses the system clock
+	Clock Clock
+
+	// MaxRequestSize is the largest multipart body, in bytes, sent when adding torrent files.
+	// Larger batches are split over several requests. Zero uses DefaultMaxRequestSize and
+	// a negative value never splits.
+	MaxRequestSize int64
 }
 
 func NewClient(cfg Config) *Client {
@@ -59,20 +93,25 @@ func NewClient(cfg Config) *Client {
 		cfg:     cfg,
 		log:     log.New(io.Discard, "", log.LstdFlags),
 		timeout: DefaultTimeout,
+
+		maxRequestSize: DefaultMaxRequestSize,
 	}
 
 	// override logger if we pass one
 	if cfg.Log != nil {
 		c.log = cfg.Log
 	}
+	if cfg.Logger != nil {
+		c.logger = cfg.Logger
+	}
 
 	if cfg.Timeout > 0 {
 		c.timeout = time.Duration(cfg.Timeout) * time.Second
 	}
 
 	// set retry defaults
-	c.retryAttempts = 5
-	c.retryDelay = 1
+	c.retryAttempts = DefaultRetryAttempts
+	c.retryDelay = DefaultRetryBaseDelay
 
 	if cfg.RetryAttempts > 0 {
 		c.retryAttempts = cfg.RetryAttempts
@@ -82,6 +121,14 @@ func NewClient(cfg Config) *Client {
 		c.retryDelay = time.Duration(cfg.RetryDelay) * time.Second
 	}
 
+	if cfg.Metrics != nil {
+		c.Use(cfg.Metrics.Middleware())
+	}
+
+	if cfg.MaxRequestSize != 0 {
+		c.maxRequestSize = cfg.MaxRequestSize
+	}
+
 	//store cookies in jar
 	jarOptions := &cookiejar.Options{PublicSuffixList: publicsuffix.List}
 	jar, err := cookiejar.New(jarOptions)
@@ -0,0 +1,640 @@
+//go:generate go run internal/codegen/generate_torrent_query.go
+
+package qbittorrent
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/autobrr/go-qbittorrent/errors"
+)
+
+// TorrentQuery is a parsed torrent filter expression. Expressions compare Torrent fields,
+// named by their JSON tags, against literals and combine them with and, or, not and parentheses:
+//
+//	category in ("tv", "movies") and ratio >= 2 and tracker ~ "example.org" and added_on < now-30d
+//
+// Supported operators are = (or ==), !=, <, <=, >, >=, ~ (case-insensitive substring), !~,
+// in (...) and not in (...). Numbers accept duration suffixes (s, m, h, d, w), which are
+// converted to seconds, and size suffixes (kb, mb, gb, tb, kib, mib, gib, tib), converted to bytes.
+// now evaluates to the current unix time, optionally offset as in now-7d.
+// On the tags field, =, != and in match individual tags instead of the whole string.
+type TorrentQuery struct {
+	source string
+	root   queryNode
+}
+
+type queryKind int
+
+const (
+	queryKindString queryKind = iota
+	queryKindNumber
+	queryKindBool
+)
+
+func (k queryKind) String() string {
+	switch k {
+	case queryKindString:
+		return "string"
+	case queryKindNumber:
+		return "number"
+	default:
+		return "bool"
+	}
+}
+
+// queryField reads a single Torrent field; exactly one accessor is set depending on kind
+type queryField struct {
+	kind    queryKind
+	str     func(t *Torrent) string
+	number  func(t *Torrent) float64
+	boolean func(t *Torrent) bool
+}
+
+// ParseTorrentQuery parses a query expression. Errors wrap ErrInvalidQuery.
+func ParseTorrentQuery(query string) (*TorrentQuery, error) {
+	tokens, err := lexQuery(query)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &queryParser{tokens: tokens}
+
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if tok := p.peek(); tok.kind != queryTokEOF {
+		return nil, p.errorf(tok, "unexpected %q", tok.text)
+	}
+
+	return &TorrentQuery{source: query, root: root}, nil
+}
+
+// String returns the query as it was parsed.
+func (q *TorrentQuery) String() string {
+	return q.source
+}
+
+// Match reports whether torrent matches the query.
+func (q *TorrentQuery) Match(torrent Torrent) bool {
+	return q.root.eval(&torrent, float64(time.Now().Unix()))
+}
+
+// Filter returns the torrents matching the query, preserving their order.
+func (q *TorrentQuery) Filter(torrents []Torrent) []Torrent {
+	now := float64(time.Now().Unix())
+
+	result := make([]Torrent, 0, len(torrents))
+	for i := range torrents {
+		if q.root.eval(&torrents[i], now) {
+			result = append(result, torrents[i])
+		}
+	}
+
+	return result
+}
+
+// QueryTorrents returns the torrents matching both query and options
+func (sm *SyncManager) QueryTorrents(query *TorrentQuery, options TorrentFilterOptions) []Torrent {
+	sm.ensureFreshData()
+	return sm.QueryTorrentsUnchecked(query, options)
+}
+
+// QueryTorrentsUnchecked returns the torrents matching both query and options without checking freshness.
+// Category, tag, state and hash options narrow the candidates through the secondary indexes before
+// the query runs; sorting, offset and limit apply to the query results.
+func (sm *SyncManager) QueryTorrentsUnchecked(query *TorrentQuery, options TorrentFilterOptions) []Torrent {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	if sm.data == nil {
+		return nil
+	}
+
+	candidates := sm.collectMatchingTorrents(nil, options)
+	return applyTorrentFilterOptions(query.Filter(candidates), options)
+}
+
+// QueryTorrents fetches the torrents matching options from qBittorrent and filters them with query.
+func (c *Client) QueryTorrents(query *TorrentQuery, options TorrentFilterOptions) ([]Torrent, error) {
+	return c.QueryTorrentsCtx(context.Background(), query, options)
+}
+
+// QueryTorrentsCtx fetches the torrents matching options from qBittorrent and filters them with query.
+// Sorting, offset and limit are applied locally after the query so they page through matches only.
+func (c *Client) QueryTorrentsCtx(ctx context.Context, query *TorrentQuery, options TorrentFilterOptions) ([]Torrent, error) {
+	remote := options
+	remote.Sort = ""
+	remote.Reverse = false
+	remote.Limit = 0
+	remote.Offset = 0
+
+	torrents, err := c.GetTorrentsCtx(ctx, remote)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not query torrents")
+	}
+
+	return applyTorrentFilterOptions(query.Filter(torrents), options), nil
+}
+
+// AST
+
+type queryNode interface {
+	eval(t *Torrent, now float64) bool
+}
+
+type queryAnd struct{ left, right queryNode }
+
+func (n queryAnd) eval(t *Torrent, now float64) bool {
+	return n.left.eval(t, now) && n.right.eval(t, now)
+}
+
+type queryOr struct{ left, right queryNode }
+
+func (n queryOr) eval(t *Torrent, now float64) bool {
+	return n.left.eval(t, now) || n.right.eval(t, now)
+}
+
+type queryNot struct{ node queryNode }
+
+func (n queryNot) eval(t *Torrent, now float64) bool {
+	return !n.node.eval(t, now)
+}
+
+// queryValue is a literal; relative values are offsets from now
+type queryValue struct {
+	kind     queryKind
+	str      string
+	number   float64
+	boolean  bool
+	relative bool
+}
+
+func (v queryValue) numberAt(now float64) float64 {
+	if v.relative {
+		return now + v.number
+	}
+	return v.number
+}
+
+type queryCompare struct {
+	name   string
+	field  queryField
+	op     string
+	values []queryValue
+}
+
+func (n queryCompare) eval(t *Torrent, now float64) bool {
+	switch n.field.kind {
+	case queryKindString:
+		return n.evalString(n.field.str(t))
+	case queryKindNumber:
+		return n.evalNumber(n.field.number(t), now)
+	default:
+		actual := n.field.boolean(t)
+		if n.op == "!=" {
+			return actual != n.values[0].boolean
+		}
+		return actual == n.values[0].boolean
+	}
+}
+
+func (n queryCompare) evalString(actual string) bool {
+	equal := func(v queryValue) bool { return actual == v.str }
+	if n.name == "tags" {
+		equal = func(v queryValue) bool { return containsExactTag(actual, v.str) }
+	}
+
+	switch n.op {
+	case "=":
+		return equal(n.values[0])
+	case "!=":
+		return !equal(n.values[0])
+	case "in":
+		return slices.ContainsFunc(n.values, equal)
+	case "not in":
+		return !slices.ContainsFunc(n.values, equal)
+	case "~":
+		return strings.Contains(strings.ToLower(actual), n.values[0].str)
+	case "!~":
+		return !strings.Contains(strings.ToLower(actual), n.values[0].str)
+	default:
+		return compareOrdered(strings.Compare(actual, n.values[0].str), n.op)
+	}
+}
+
+func (n queryCompare) evalNumber(actual, now float64) bool {
+	switch n.op {
+	case "in", "not in":
+		found := slices.ContainsFunc(n.values, func(v queryValue) bool { return actual == v.numberAt(now) })
+		return found == (n.op == "in")
+	}
+
+	expected := n.values[0].numberAt(now)
+	switch {
+	case actual < expected:
+		return compareOrdered(-1, n.op)
+	case actual > expected:
+		return compareOrdered(1, n.op)
+	default:
+		return compareOrdered(0, n.op)
+	}
+}
+
+func compareOrdered(cmp int, op string) bool {
+	switch op {
+	case "=":
+		return cmp == 0
+	case "!=":
+		return cmp != 0
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	default:
+		return false
+	}
+}
+
+// Lexer
+
+type queryTokKind int
+
+const (
+	queryTokEOF queryTokKind = iota
+	queryTokIdent
+	queryTokString
+	queryTokNumber
+	queryTokOp
+	queryTokLParen
+	queryTokRParen
+	queryTokComma
+	queryTokPlus
+	queryTokMinus
+)
+
+type queryToken struct {
+	kind   queryTokKind
+	text   string
+	number float64
+	pos    int
+}
+
+// queryUnits converts number suffixes to seconds or bytes
+var queryUnits = map[string]float64{
+	"s":   1,
+	"m":   60,
+	"h":   60 * 60,
+	"d":   24 * 60 * 60,
+	"w":   7 * 24 * 60 * 60,
+	"b":   1,
+	"kb":  1e3,
+	"mb":  1e6,
+	"gb":  1e9,
+	"tb":  1e12,
+	"kib": 1 << 10,
+	"mib": 1 << 20,
+	"gib": 1 << 30,
+	"tib": 1 << 40,
+}
+
+func queryError(pos int, format string, args ...interface{}) error {
+	return errors.Wrap(ErrInvalidQuery, "position %d: %s", pos, fmt.Sprintf(format, args...))
+}
+
+func lexQuery(input string) ([]queryToken, error) {
+	var tokens []queryToken
+
+	for i := 0; i < len(input); {
+		c := input[i]
+
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+
+		case c == '(':
+			tokens = append(tokens, queryToken{kind: queryTokLParen, text: "(", pos: i})
+			i++
+		case c == ')':
+			tokens = append(tokens, queryToken{kind: queryTokRParen, text: ")", pos: i})
+			i++
+		case c == ',':
+			tokens = append(tokens, queryToken{kind: queryTokComma, text: ",", pos: i})
+			i++
+		case c == '+':
+			tokens = append(tokens, queryToken{kind: queryTokPlus, text: "+", pos: i})
+			i++
+		case c == '-':
+			tokens = append(tokens, queryToken{kind: queryTokMinus, text: "-", pos: i})
+			i++
+
+		case strings.ContainsRune("=!<>~", rune(c)):
+			start := i
+			op := input[i:min(i+2, len(input))]
+			switch op {
+			case "==", "!=", "<=", ">=", "!~":
+			default:
+				op = string(c)
+				if op == "!" {
+					return nil, queryError(i, "unexpected %q", op)
+				}
+			}
+			i += len(op)
+
+			if op == "==" {
+				op = "="
+			}
+			tokens = append(tokens, queryToken{kind: queryTokOp, text: op, pos: start})
+
+		case c == '"' || c == '\'':
+			start := i
+			i++
+			var b strings.Builder
+			for ; i < len(input) && input[i] != c; i++ {
+				if input[i] == '\\' && i+1 < len(input) {
+					i++
+				}
+				b.WriteByte(input[i])
+			}
+			if i >= len(input) {
+				return nil, queryError(start, "unterminated string")
+			}
+			i++
+			tokens = append(tokens, queryToken{kind: queryTokString, text: b.String(), pos: start})
+
+		case c >= '0' && c <= '9' || c == '.':
+			start := i
+			for i < len(input) && (input[i] >= '0' && input[i] <= '9' || input[i] == '.') {
+				i++
+			}
+			value, err := strconv.ParseFloat(input[start:i], 64)
+			if err != nil {
+				return nil, queryError(start, "invalid number %q", input[start:i])
+			}
+
+			unitStart := i
+			for i < len(input) && unicode.IsLetter(rune(input[i])) {
+				i++
+			}
+			if unit := strings.ToLower(input[unitStart:i]); unit != "" {
+				multiplier, ok := queryUnits[unit]
+				if !ok {
+					return nil, queryError(unitStart, "unknown unit %q", unit)
+				}
+				value *= multiplier
+			}
+
+			tokens = append(tokens, queryToken{kind: queryTokNumber, text: input[start:i], number: value, pos: start})
+
+		case c == '_' || unicode.IsLetter(rune(c)):
+			start := i
+			for i < len(input) && (input[i] == '_' || unicode.IsLetter(rune(input[i])) || input[i] >= '0' && input[i] <= '9') {
+				i++
+			}
+			tokens = append(tokens, queryToken{kind: queryTokIdent, text: input[start:i], pos: start})
+
+		default:
+			return nil, queryError(i, "unexpected character %q", c)
+		}
+	}
+
+	return append(tokens, queryToken{kind: queryTokEOF, text: "end of query", pos: len(input)}), nil
+}
+
+// Parser
+
+type queryParser struct {
+	tokens []queryToken
+	pos    int
+}
+
+func (p *queryParser) peek() queryToken {
+	return p.tokens[p.pos]
+}
+
+func (p *queryParser) next() queryToken {
+	tok := p.tokens[p.pos]
+	if tok.kind != queryTokEOF {
+		p.pos++
+	}
+	return tok
+}
+
+// keyword reports whether the next token is the given case-insensitive keyword and consumes it
+func (p *queryParser) keyword(word string) bool {
+	tok := p.peek()
+	if tok.kind == queryTokIdent && strings.EqualFold(tok.text, word) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *queryParser) errorf(tok queryToken, format string, args ...interface{}) error {
+	return queryError(tok.pos, format, args...)
+}
+
+func (p *queryParser) parseOr() (queryNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.keyword("or") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = queryOr{left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (p *queryParser) parseAnd() (queryNode, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.keyword("and") {
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = queryAnd{left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (p *queryParser) parseNot() (queryNode, error) {
+	if p.keyword("not") {
+		node, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return queryNot{node: node}, nil
+	}
+
+	if p.peek().kind == queryTokLParen {
+		p.next()
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if tok := p.next(); tok.kind != queryTokRParen {
+			return nil, p.errorf(tok, "expected ) but found %q", tok.text)
+		}
+		return node, nil
+	}
+
+	return p.parseComparison()
+}
+
+func (p *queryParser) parseComparison() (queryNode, error) {
+	tok := p.next()
+	if tok.kind != queryTokIdent {
+		return nil, p.errorf(tok, "expected field name but found %q", tok.text)
+	}
+
+	name := strings.ToLower(tok.text)
+	field, ok := torrentQueryFields[name]
+	if !ok {
+		return nil, p.errorf(tok, "unknown field %q", tok.text)
+	}
+
+	node := queryCompare{name: name, field: field}
+
+	opTok := p.peek()
+	switch {
+	case p.keyword("in"):
+		node.op = "in"
+	case p.keyword("not"):
+		if !p.keyword("in") {
+			return nil, p.errorf(p.peek(), "expected in after not")
+		}
+		node.op = "not in"
+	case opTok.kind == queryTokOp:
+		node.op = p.next().text
+	default:
+		return nil, p.errorf(opTok, "expected operator after %s but found %q", name, opTok.text)
+	}
+
+	if !queryOperatorSupported(field.kind, node.op) {
+		return nil, p.errorf(opTok, "operator %s is not supported on %s field %s", node.op, field.kind, name)
+	}
+
+	if node.op == "in" || node.op == "not in" {
+		values, err := p.parseList(field.kind)
+		if err != nil {
+			return nil, err
+		}
+		node.values = values
+		return node, nil
+	}
+
+	value, err := p.parseValue(field.kind)
+	if err != nil {
+		return nil, err
+	}
+
+	if node.op == "~" || node.op == "!~" {
+		value.str = strings.ToLower(value.str)
+	}
+
+	node.values = []queryValue{value}
+	return node, nil
+}
+
+func queryOperatorSupported(kind queryKind, op string) bool {
+	switch kind {
+	case queryKindBool:
+		return op == "=" || op == "!="
+	case queryKindNumber:
+		return op != "~" && op != "!~"
+	default:
+		return true
+	}
+}
+
+func (p *queryParser) parseList(kind queryKind) ([]queryValue, error) {
+	if tok := p.next(); tok.kind != queryTokLParen {
+		return nil, p.errorf(tok, "expected ( but found %q", tok.text)
+	}
+
+	var values []queryValue
+	for {
+		value, err := p.parseValue(kind)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+
+		tok := p.next()
+		if tok.kind == queryTokRParen {
+			return values, nil
+		}
+		if tok.kind != queryTokComma {
+			return nil, p.errorf(tok, "expected , or ) but found %q", tok.text)
+		}
+	}
+}
+
+func (p *queryParser) parseValue(kind queryKind) (queryValue, error) {
+	tok := p.next()
+
+	switch kind {
+	case queryKindString:
+		if tok.kind != queryTokString {
+			return queryValue{}, p.errorf(tok, "expected string but found %q", tok.text)
+		}
+		return queryValue{kind: kind, str: tok.text}, nil
+
+	case queryKindBool:
+		if tok.kind == queryTokIdent {
+			if b, err := strconv.ParseBool(strings.ToLower(tok.text)); err == nil {
+				return queryValue{kind: kind, boolean: b}, nil
+			}
+		}
+		return queryValue{}, p.errorf(tok, "expected true or false but found %q", tok.text)
+	}
+
+	switch {
+	case tok.kind == queryTokNumber:
+		return queryValue{kind: kind, number: tok.number}, nil
+
+	case tok.kind == queryTokMinus && p.peek().kind == queryTokNumber:
+		return queryValue{kind: kind, number: -p.next().number}, nil
+
+	case tok.kind == queryTokIdent && strings.EqualFold(tok.text, "now"):
+		value := queryValue{kind: kind, relative: true}
+
+		if sign := p.peek(); sign.kind == queryTokPlus || sign.kind == queryTokMinus {
+			p.next()
+			offset := p.next()
+			if offset.kind != queryTokNumber {
+				return queryValue{}, p.errorf(offset, "expected duration after now%s but found %q", sign.text, offset.text)
+			}
+			value.number = offset.number
+			if sign.kind == queryTokMinus {
+				value.number = -value.number
+			}
+		}
+
+		return value, nil
+	}
+
+	return queryValue{}, p.errorf(tok, "expected number but found %q", tok.text)
+}
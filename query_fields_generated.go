@@ -0,0 +1,66 @@
+// Code generated by go generate; DO NOT EDIT.
+// This file was generated by internal/codegen/generate_torrent_query.go
+
+package qbittorrent
+
+// torrentQueryFields maps Torrent JSON field names to their query accessors
+var torrentQueryFields = map[string]queryField{
+	"added_on":                    {kind: queryKindNumber, number: func(t *Torrent) float64 { return float64(t.AddedOn) }},
+	"amount_left":                 {kind: queryKindNumber, number: func(t *Torrent) float64 { return float64(t.AmountLeft) }},
+	"auto_tmm":                    {kind: queryKindBool, boolean: func(t *Torrent) bool { return t.AutoManaged }},
+	"availability":                {kind: queryKindNumber, number: func(t *Torrent) float64 { return float64(t.Availability) }},
+	"category":                    {kind: queryKindString, str: func(t *Torrent) string { return t.Category }},
+	"comment":                     {kind: queryKindString, str: func(t *Torrent) string { return t.Comment }},
+	"completed":                   {kind: queryKindNumber, number: func(t *Torrent) float64 { return float64(t.Completed) }},
+	"completion_on":               {kind: queryKindNumber, number: func(t *Torrent) float64 { return float64(t.CompletionOn) }},
+	"created_by":                  {kind: queryKindString, str: func(t *Torrent) string { return t.CreatedBy }},
+	"content_path":                {kind: queryKindString, str: func(t *Torrent) string { return t.ContentPath }},
+	"dl_limit":                    {kind: queryKindNumber, number: func(t *Torrent) float64 { return float64(t.DlLimit) }},
+	"dlspeed":                     {kind: queryKindNumber, number: func(t *Torrent) float64 { return float64(t.DlSpeed) }},
+	"download_path":               {kind: queryKindString, str: func(t *Torrent) string { return t.DownloadPath }},
+	"downloaded":                  {kind: queryKindNumber, number: func(t *Torrent) float64 { return float64(t.Downloaded) }},
+	"downloaded_session":          {kind: queryKindNumber, number: func(t *Torrent) float64 { return float64(t.DownloadedSession) }},
+	"eta":                         {kind: queryKindNumber, number: func(t *Torrent) float64 { return float64(t.ETA) }},
+	"f_l_piece_prio":              {kind: queryKindBool, boolean: func(t *Torrent) bool { return t.FirstLastPiecePrio }},
+	"force_start":                 {kind: queryKindBool, boolean: func(t *Torrent) bool { return t.ForceStart }},
+	"hash":                        {kind: queryKindString, str: func(t *Torrent) string { return t.Hash }},
+	"infohash_v1":                 {kind: queryKindString, str: func(t *Torrent) string { return t.InfohashV1 }},
+	"infohash_v2":                 {kind: queryKindString, str: func(t *Torrent) string { return t.InfohashV2 }},
+	"popularity":                  {kind: queryKindNumber, number: func(t *Torrent) float64 { return float64(t.Popularity) }},
+	"private":                     {kind: queryKindBool, boolean: func(t *Torrent) bool { return t.Private }},
+	"last_activity":               {kind: queryKindNumber, number: func(t *Torrent) float64 { return float64(t.LastActivity) }},
+	"magnet_uri":                  {kind: queryKindString, str: func(t *Torrent) string { return t.MagnetURI }},
+	"max_ratio":                   {kind: queryKindNumber, number: func(t *Torrent) float64 { return float64(t.MaxRatio) }},
+	"max_seeding_time":            {kind: queryKindNumber, number: func(t *Torrent) float64 { return float64(t.MaxSeedingTime) }},
+	"max_inactive_seeding_time":   {kind: queryKindNumber, number: func(t *Torrent) float64 { return float64(t.MaxInactiveSeedingTime) }},
+	"name":                        {kind: queryKindString, str: func(t *Torrent) string { return t.Name }},
+	"num_complete":                {kind: queryKindNumber, number: func(t *Torrent) float64 { return float64(t.NumComplete) }},
+	"num_incomplete":              {kind: queryKindNumber, number: func(t *Torrent) float64 { return float64(t.NumIncomplete) }},
+	"num_leechs":                  {kind: queryKindNumber, number: func(t *Torrent) float64 { return float64(t.NumLeechs) }},
+	"num_seeds":                   {kind: queryKindNumber, number: func(t *Torrent) float64 { return float64(t.NumSeeds) }},
+	"priority":                    {kind: queryKindNumber, number: func(t *Torrent) float64 { return float64(t.Priority) }},
+	"progress":                    {kind: queryKindNumber, number: func(t *Torrent) float64 { return float64(t.Progress) }},
+	"ratio":                       {kind: queryKindNumber, number: func(t *Torrent) float64 { return float64(t.Ratio) }},
+	"ratio_limit":                 {kind: queryKindNumber, number: func(t *Torrent) float64 { return float64(t.RatioLimit) }},
+	"reannounce":                  {kind: queryKindNumber, number: func(t *Torrent) float64 { return float64(t.Reannounce) }},
+	"save_path":                   {kind: queryKindString, str: func(t *Torrent) string { return t.SavePath }},
+	"seeding_time":                {kind: queryKindNumber, number: func(t *Torrent) float64 { return float64(t.SeedingTime) }},
+	"seeding_time_limit":          {kind: queryKindNumber, number: func(t *Torrent) float64 { return float64(t.SeedingTimeLimit) }},
+	"inactive_seeding_time_limit": {kind: queryKindNumber, number: func(t *Torrent) float64 { return float64(t.InactiveSeedingTimeLimit) }},
+	"share_limit_action":          {kind: queryKindString, str: func(t *Torrent) string { return t.ShareLimitAction }},
+	"share_limits_mode":           {kind: queryKindString, str: func(t *Torrent) string { return t.ShareLimitsMode }},
+	"seen_complete":               {kind: queryKindNumber, number: func(t *Torrent) float64 { return float64(t.SeenComplete) }},
+	"seq_dl":                      {kind: queryKindBool, boolean: func(t *Torrent) bool { return t.SequentialDownload }},
+	"size":                        {kind: queryKindNumber, number: func(t *Torrent) float64 { return float64(t.Size) }},
+	"state":                       {kind: queryKindString, str: func(t *Torrent) string { return string(t.State) }},
+	"super_seeding":               {kind: queryKindBool, boolean: func(t *Torrent) bool { return t.SuperSeeding }},
+	"tags":                        {kind: queryKindString, str: func(t *Torrent) string { return t.Tags }},
+	"time_active":                 {kind: queryKindNumber, number: func(t *Torrent) float64 { return float64(t.TimeActive) }},
+	"total_size":                  {kind: queryKindNumber, number: func(t *Torrent) float64 { return float64(t.TotalSize) }},
+	"tracker":                     {kind: queryKindString, str: func(t *Torrent) string { return t.Tracker }},
+	"trackers_count":              {kind: queryKindNumber, number: func(t *Torrent) float64 { return float64(t.TrackersCount) }},
+	"up_limit":                    {kind: queryKindNumber, number: func(t *Torrent) float64 { return float64(t.UpLimit) }},
+	"uploaded":                    {kind: queryKindNumber, number: func(t *Torrent) float64 { return float64(t.Uploaded) }},
+	"uploaded_session":            {kind: queryKindNumber, number: func(t *Torrent) float64 { return float64(t.UploadedSession) }},
+	"upspeed":                     {kind: queryKindNumber, number: func(t *Torrent) float64 { return float64(t.UpSpeed) }},
+}
@@ -0,0 +1,157 @@
+package qbittorrent
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/autobrr/go-qbittorrent/errors"
+)
+
+func queryTestTorrents() []Torrent {
+	now := time.Now().Unix()
+	day := int64(24 * 60 * 60)
+
+	return []Torrent{
+		{Hash: "a", Name: "Show.S01", Category: "tv", Tags: "hd, keep", Ratio: 2.5, Tracker: "https://tracker.Example.org/announce", AddedOn: now - 40*day, Size: 4 << 30, State: TorrentStateUploading, Private: true},
+		{Hash: "b", Name: "Movie", Category: "movies", Tags: "sd", Ratio: 0.4, Tracker: "https://other.net/announce", AddedOn: now - 40*day, Size: 700 << 20, State: TorrentStateStalledUp},
+		{Hash: "c", Name: "Show.S02", Category: "tv", Tags: "hd", Ratio: 3, Tracker: "https://example.org/announce", AddedOn: now - 2*day, Size: 8 << 30, State: TorrentStateDownloading},
+		{Hash: "d", Name: "Linux ISO", Category: "", Tags: "", Ratio: 10, Tracker: "", AddedOn: now - 400*day, Size: 2 << 30, State: TorrentStateStoppedUp},
+	}
+}
+
+func TestTorrentQuery(t *testing.T) {
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{`category in ("tv", "movies") and ratio >= 2 and tracker ~ "example.org" and added_on < now-30d`, []string{"a"}},
+		{`category = "tv"`, []string{"a", "c"}},
+		{`category == "tv" or category = ""`, []string{"a", "c", "d"}},
+		{`category not in ("tv")`, []string{"b", "d"}},
+		{`not (category = "tv")`, []string{"b", "d"}},
+		{`tags = "hd"`, []string{"a", "c"}},
+		{`tags in ("keep", "sd")`, []string{"a", "b"}},
+		{`tags != "hd"`, []string{"b", "d"}},
+		{`tracker !~ "EXAMPLE"`, []string{"b", "d"}},
+		{`size > 3GiB`, []string{"a", "c"}},
+		{`size <= 700mib`, []string{"b"}},
+		{`added_on >= now - 7d`, []string{"c"}},
+		{`added_on < now-52w`, []string{"d"}},
+		{`private = true`, []string{"a"}},
+		{`PRIVATE != TRUE and state in ("stalledUP", "stoppedUP")`, []string{"b", "d"}},
+		{`ratio > -1 and ratio < 1 or name = "Linux ISO"`, []string{"b", "d"}},
+		{`ratio in (2.5, 10)`, []string{"a", "d"}},
+		{`name >= "Show"`, []string{"a", "c"}},
+	}
+
+	torrents := queryTestTorrents()
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			query, err := ParseTorrentQuery(tt.query)
+			require.NoError(t, err)
+			assert.Equal(t, tt.query, query.String())
+
+			var got []string
+			for _, torrent := range query.Filter(torrents) {
+				got = append(got, torrent.Hash)
+			}
+			assert.Equal(t, tt.want, got)
+
+			for _, torrent := range torrents {
+				assert.Equal(t, slices.Contains(tt.want, torrent.Hash), query.Match(torrent), torrent.Hash)
+			}
+		})
+	}
+}
+
+func TestParseTorrentQuery_Errors(t *testing.T) {
+	tests := []string{
+		``,
+		`category`,
+		`nope = "x"`,
+		`category = 5`,
+		`ratio = "high"`,
+		`ratio ~ "2"`,
+		`private > true`,
+		`private = maybe`,
+		`category in "tv"`,
+		`category in ("tv" "movies")`,
+		`(category = "tv"`,
+		`category = "tv" and`,
+		`category = "tv`,
+		`size > 5parsecs`,
+		`added_on < now-`,
+		`category ! "tv"`,
+		`trackers = "x"`,
+		`category = "tv" extra`,
+	}
+
+	for _, query := range tests {
+		t.Run(query, func(t *testing.T) {
+			_, err := ParseTorrentQuery(query)
+			assert.True(t, errors.Is(err, ErrInvalidQuery), "got %v", err)
+		})
+	}
+}
+
+func TestSyncManager_QueryTorrents(t *testing.T) {
+	torrents := map[string]Torrent{}
+	for _, torrent := range queryTestTorrents() {
+		torrents[torrent.Hash] = torrent
+	}
+
+	body, err := json.Marshal(map[string]interface{}{"rid": 1, "full_update": true, "torrents": torrents})
+	require.NoError(t, err)
+
+	sm := newSyncManagerWithBodies(string(body))
+	require.NoError(t, sm.Sync(context.Background()))
+
+	query, err := ParseTorrentQuery(`ratio >= 2`)
+	require.NoError(t, err)
+
+	result := sm.QueryTorrentsUnchecked(query, TorrentFilterOptions{Category: "tv", Sort: "ratio", Reverse: true})
+	require.Len(t, result, 2)
+	assert.Equal(t, "c", result[0].Hash)
+	assert.Equal(t, "a", result[1].Hash)
+
+	// limit applies to the query matches, not to the candidates
+	result = sm.QueryTorrentsUnchecked(query, TorrentFilterOptions{Sort: "ratio", Limit: 1})
+	require.Len(t, result, 1)
+	assert.Equal(t, "a", result[0].Hash)
+}
+
+func TestClient_QueryTorrents(t *testing.T) {
+	var rawQuery string
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v2/torrents/info", func(w http.ResponseWriter, r *http.Request) {
+		rawQuery = r.URL.RawQuery
+		require.NoError(t, json.NewEncoder(w).Encode(queryTestTorrents()))
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := NewClient(Config{Host: server.URL})
+
+	query, err := ParseTorrentQuery(`tracker ~ "example.org"`)
+	require.NoError(t, err)
+
+	result, err := client.QueryTorrents(query, TorrentFilterOptions{Category: "tv", Limit: 1, Sort: "added_on"})
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+	assert.Equal(t, "a", result[0].Hash)
+
+	assert.Contains(t, rawQuery, "category=tv")
+	assert.NotContains(t, rawQuery, "limit")
+	assert.NotContains(t, rawQuery, "sort")
+}
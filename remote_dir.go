@@ -0,0 +1,75 @@
+package qbittorrent
+
+import (
+	"context"
+	"slices"
+
+	"github.com/autobrr/go-qbittorrent/errors"
+)
+
+// SkipRemoteDir can be returned by a RemoteDirWalkFunc to skip the children of the visited directory.
+var SkipRemoteDir = errors.Sentinel("skip this remote directory")
+
+// RemoteDirWalkFunc is called by WalkRemoteDir for each visited directory.
+// depth is 0 for the root and increases by one for every level below it.
+// Returning SkipRemoteDir skips the directory's children; any other error stops the walk.
+type RemoteDirWalkFunc func(path string, depth int) error
+
+// WalkRemoteDirOptions configures WalkRemoteDir.
+type WalkRemoteDirOptions struct {
+	// MaxDepth limits how deep the walk descends below root; 0 means no limit
+	MaxDepth int
+}
+
+// WalkRemoteDir walks the directory tree on the qBittorrent host rooted at root, calling fn for
+// root and each directory below it in lexical order. Only directories are visited.
+// Requires qBittorrent 5.0 and WebAPI >= 2.11.2.
+func (c *Client) WalkRemoteDir(ctx context.Context, root string, opts WalkRemoteDirOptions, fn RemoteDirWalkFunc) error {
+	err := c.walkRemoteDir(ctx, root, 0, opts, fn)
+	if errors.Is(err, SkipRemoteDir) {
+		return nil
+	}
+
+	return err
+}
+
+func (c *Client) walkRemoteDir(ctx context.Context, path string, depth int, opts WalkRemoteDirOptions, fn RemoteDirWalkFunc) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if err := fn(path, depth); err != nil {
+		return err
+	}
+
+	if opts.MaxDepth > 0 && depth >= opts.MaxDepth {
+		return nil
+	}
+
+	// fn may have taken long enough for the caller to give up
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	children, err := c.GetDirectoryPathsCtx(ctx, path)
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+
+		return errors.Wrap(err, "could not list remote directory: %s", path)
+	}
+
+	slices.Sort(children)
+
+	for _, child := range children {
+		if err := c.walkRemoteDir(ctx, child, depth+1, opts, fn); err != nil {
+			if errors.Is(err, SkipRemoteDir) {
+				continue
+			}
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,156 @@
+package qbittorrent
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newRemoteDirTestServer(t *testing.T, tree map[string][]string) *httptest.Server {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v2/app/webapiVersion", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("2.11.2"))
+	})
+	mux.HandleFunc("/api/v2/app/getDirectoryContent", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "dirs", r.URL.Query().Get("mode"))
+
+		children, ok := tree[r.URL.Query().Get("dirPath")]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(children)
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestClient_GetDirectoryContentTyped(t *testing.T) {
+	server := newRemoteDirTestServer(t, map[string][]string{
+		"/data": {"/data/movies", "/data/tv"},
+	})
+
+	client := NewClient(Config{Host: server.URL})
+
+	paths, err := client.GetDirectoryPaths("/data")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"/data/movies", "/data/tv"}, paths)
+
+	_, err = client.GetDirectoryPaths("/missing")
+	assert.ErrorIs(t, err, ErrDirectoryNotFound)
+
+	for _, withMetadata := range []bool{false, true} {
+		res, err := client.GetDirectoryContent("/missing", withMetadata)
+		assert.ErrorIs(t, err, ErrDirectoryNotFound)
+		assert.True(t, res == nil, "errors return an untyped nil")
+	}
+}
+
+func TestClient_WalkRemoteDir(t *testing.T) {
+	tree := map[string][]string{
+		"/data":           {"/data/tv", "/data/movies"},
+		"/data/movies":    {"/data/movies/4k"},
+		"/data/movies/4k": {},
+		"/data/tv":        {"/data/tv/anime"},
+		"/data/tv/anime":  {"/data/tv/anime/old"},
+	}
+
+	type visit struct {
+		path  string
+		depth int
+	}
+
+	tests := []struct {
+		name string
+		opts WalkRemoteDirOptions
+		skip string
+		want []visit
+	}{
+		{
+			name: "unlimited",
+			want: []visit{
+				{"/data", 0},
+				{"/data/movies", 1},
+				{"/data/movies/4k", 2},
+				{"/data/tv", 1},
+				{"/data/tv/anime", 2},
+				{"/data/tv/anime/old", 3},
+			},
+		},
+		{
+			name: "max depth",
+			opts: WalkRemoteDirOptions{MaxDepth: 1},
+			want: []visit{
+				{"/data", 0},
+				{"/data/movies", 1},
+				{"/data/tv", 1},
+			},
+		},
+		{
+			name: "skip dir",
+			skip: "/data/tv",
+			want: []visit{
+				{"/data", 0},
+				{"/data/movies", 1},
+				{"/data/movies/4k", 2},
+				{"/data/tv", 1},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// /data/tv/anime/old is not in the tree, so it is always skipped to avoid a 404
+			server := newRemoteDirTestServer(t, tree)
+			client := NewClient(Config{Host: server.URL})
+
+			var got []visit
+			err := client.WalkRemoteDir(context.Background(), "/data", tt.opts, func(path string, depth int) error {
+				got = append(got, visit{path, depth})
+				if path == tt.skip || path == "/data/tv/anime/old" {
+					return SkipRemoteDir
+				}
+				return nil
+			})
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestClient_WalkRemoteDir_Cancelled(t *testing.T) {
+	server := newRemoteDirTestServer(t, map[string][]string{
+		"/data":   {"/data/a", "/data/b"},
+		"/data/a": {},
+		"/data/b": {},
+	})
+	client := NewClient(Config{Host: server.URL})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var visited []string
+	err := client.WalkRemoteDir(ctx, "/data", WalkRemoteDirOptions{}, func(path string, _ int) error {
+		visited = append(visited, path)
+		if path == "/data/a" {
+			cancel()
+		}
+		return nil
+	})
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, []string{"/data", "/data/a"}, visited)
+}
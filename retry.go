@@ -0,0 +1,300 @@
+package qbittorrent
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"math/rand"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/autobrr/go-qbittorrent/errors"
+)
+
+// Defaults of BackoffPolicy
+const (
+	DefaultRetryAttempts  = 5
+	DefaultRetryBaseDelay = 100 * time.Millisecond
+	DefaultRetryMaxDelay  = 5 * time.Second
+	DefaultMaxRetryAfter  = time.Minute
+)
+
+// DefaultRetryStatuses are the response codes retried by default: rate limiting and the
+// errors reverse proxies return while qBittorrent is restarting or overloaded
+var DefaultRetryStatuses = []int{
+	http.StatusTooManyRequests,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// nonIdempotentEndpoints are WebAPI methods that must not run twice, because each call
+// creates something new
+var nonIdempotentEndpoints = map[string]bool{
+	"torrents/add":           true,
+	"torrents/addPeers":      true,
+	"torrentcreator/addTask": true,
+	"search/start":           true,
+	"rss/addFeed":            true,
+	"rss/addFolder":          true,
+}
+
+// RetryAttempt is a failed attempt at a request, passed to a RetryPolicy
+type RetryAttempt struct {
+	Request *Request
+	// Attempt counts the attempts made so far, starting at 1
+	Attempt int
+	// Response is the response that is considered for a retry; it is nil when Err is set
+	Response *http.Response
+	Err      error
+	Now      time.Time
+}
+
+// RetryPolicy decides whether a failed attempt is retried, and how long to wait before
+// the next one. Responses it does not retry are returned to the caller as they are.
+type RetryPolicy interface {
+	NextRetry(attempt RetryAttempt) (time.Duration, bool)
+}
+
+// Clock is the time source of the retry loop, replaceable in tests
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time                         { return time.Now() }
+func (realClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+// BackoffPolicy retries connection errors and DefaultRetryStatuses with exponential backoff and
+// full jitter, and honors Retry-After. Non-idempotent requests, such as torrents/add, are only
+// retried when the server cannot have processed them. The zero value uses the defaults.
+type BackoffPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first
+	MaxAttempts int
+	// BaseDelay caps the wait before the first retry; the cap doubles for every further retry
+	BaseDelay time.Duration
+	// MaxDelay caps the wait before any retry
+	MaxDelay time.Duration
+	// MaxRetryAfter is the longest Retry-After that is waited for; longer ones give up
+	MaxRetryAfter time.Duration
+	// RetryStatuses lists the retried response codes, DefaultRetryStatuses if nil
+	RetryStatuses []int
+	// RetryNonIdempotent retries every request alike, even if it may then be processed twice
+	RetryNonIdempotent bool
+
+	// random returns a number in [0, 1); tests replace it to make jitter predictable
+	random func() float64
+}
+
+func (p *BackoffPolicy) NextRetry(a RetryAttempt) (time.Duration, bool) {
+	maxAttempts := positiveOr(p.MaxAttempts, DefaultRetryAttempts)
+	if a.Attempt >= maxAttempts || !p.retryable(a) {
+		return 0, false
+	}
+
+	if a.Response != nil {
+		if wait, ok := retryAfter(a.Response, a.Now); ok {
+			return wait, wait <= positiveOr(p.MaxRetryAfter, DefaultMaxRetryAfter)
+		}
+	}
+
+	return p.backoff(a.Attempt), true
+}
+
+func (p *BackoffPolicy) retryable(a RetryAttempt) bool {
+	if a.Err != nil {
+		if errors.Is(a.Err, context.Canceled) || errors.Is(a.Err, context.DeadlineExceeded) {
+			return false
+		}
+		if !isConnectionError(a.Err) {
+			return false
+		}
+		return p.RetryNonIdempotent || isIdempotent(a.Request) || isDialError(a.Err)
+	}
+
+	statuses := p.RetryStatuses
+	if statuses == nil {
+		statuses = DefaultRetryStatuses
+	}
+	if !slices.Contains(statuses, a.Response.StatusCode) {
+		return false
+	}
+
+	// a proxy timeout or bad gateway does not tell whether qBittorrent got the request
+	switch a.Response.StatusCode {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		return true
+	}
+	return p.RetryNonIdempotent || isIdempotent(a.Request)
+}
+
+// backoff returns a random wait of up to BaseDelay * 2^(attempt-1), capped at MaxDelay
+func (p *BackoffPolicy) backoff(attempt int) time.Duration {
+	base := positiveOr(p.BaseDelay, DefaultRetryBaseDelay)
+	maxDelay := positiveOr(p.MaxDelay, DefaultRetryMaxDelay)
+
+	ceiling := maxDelay
+	if shift := attempt - 1; shift < 32 && base<<shift > 0 && base<<shift < maxDelay {
+		ceiling = base << shift
+	}
+
+	random := p.random
+	if random == nil {
+		random = rand.Float64
+	}
+	return time.Duration(random() * float64(ceiling))
+}
+
+func positiveOr[T int | time.Duration](v, fallback T) T {
+	if v > 0 {
+		return v
+	}
+	return fallback
+}
+
+// retryAfter parses the Retry-After header, in seconds or as an HTTP date
+func retryAfter(resp *http.Response, now time.Time) (time.Duration, bool) {
+	value := resp.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return max(time.Duration(seconds)*time.Second, 0), true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(date.Sub(now), 0), true
+	}
+
+	return 0, false
+}
+
+func isIdempotent(req *Request) bool {
+	return req == nil || req.Method == http.MethodGet || !nonIdempotentEndpoints[req.Endpoint]
+}
+
+// isConnectionError reports whether err is a network failure rather than, for instance,
+// a malformed request
+func isConnectionError(err error) bool {
+	if errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF) || errors.Is(err, net.ErrClosed) ||
+		errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.EPIPE) {
+		return true
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// isDialError reports whether err happened before a connection was made, so the request
+// cannot have reached the server
+func isDialError(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// retryPolicy returns the policy for an endpoint
+func (c *Client) retryPolicy(endpoint string) RetryPolicy {
+	if policy, ok := c.cfg.EndpointRetryPolicies[endpoint]; ok {
+		return policy
+	}
+	if c.cfg.RetryPolicy != nil {
+		return c.cfg.RetryPolicy
+	}
+	return &BackoffPolicy{MaxAttempts: c.retryAttempts, BaseDelay: c.retryDelay}
+}
+
+func (c *Client) clock() Clock {
+	if c.cfg.Clock != nil {
+		return c.cfg.Clock
+	}
+	return realClock{}
+}
+
+// retryDo sends req, retrying as its endpoint's RetryPolicy allows, and logs in again once
+// if the session expired
+func (c *Client) retryDo(ctx context.Context, req *Request) (*http.Response, error) {
+	httpReq := req.HTTP
+
+	// bodies that cannot be replayed are copied once; streamed bodies are built by GetBody
+	// for every attempt, the first one included if the body was left unset
+	if httpReq.Body != nil && httpReq.GetBody == nil {
+		originalBody, err := copyBody(httpReq.Body)
+		if err != nil {
+			return nil, err
+		}
+		resetBody(httpReq, originalBody)
+	}
+
+	policy := c.retryPolicy(req.Endpoint)
+	clock := c.clock()
+	relogged := false
+	sent := false
+
+	for attempt := 1; ; attempt++ {
+		if httpReq.GetBody != nil && (sent || httpReq.Body == nil) {
+			body, err := httpReq.GetBody()
+			if err != nil {
+				return nil, errors.Wrap(err, "could not replay request body")
+			}
+			httpReq.Body = body
+		}
+
+		resp, err := c.http.Do(httpReq)
+		sent = true
+		if err == nil && resp.StatusCode == http.StatusForbidden && !c.usingAPIKeyAuth() && !relogged {
+			drainAndClose(resp)
+			relogged = true
+			c.cfg.Metrics.observeRelogin()
+			c.slogger().LogAttrs(ctx, slog.LevelInfo, "session expired, logging in again",
+				slog.String("endpoint", req.Endpoint), slog.String("host", httpReq.URL.Host))
+			if err := c.LoginCtx(ctx); err != nil {
+				return nil, errors.Wrap(err, "qbit re-login failed")
+			}
+			attempt--
+			continue
+		}
+
+		if err == nil && resp.StatusCode < http.StatusBadRequest {
+			return resp, nil
+		}
+
+		wait, retry := policy.NextRetry(RetryAttempt{Request: req, Attempt: attempt, Response: resp, Err: err, Now: clock.Now()})
+		if !retry {
+			if err != nil {
+				return nil, errors.Wrap(err, "error making request")
+			}
+			if resp.StatusCode >= http.StatusInternalServerError {
+				drainAndClose(resp)
+				return nil, errors.New("unrecoverable status: %v", resp.StatusCode)
+			}
+			return resp, nil
+		}
+
+		reason := err
+		if err == nil {
+			drainAndClose(resp)
+			reason = errors.New("status %d", resp.StatusCode)
+		} else if isConnectionError(err) {
+			// drop pooled connections that may be stale as well
+			c.http.CloseIdleConnections()
+		}
+
+		c.log.Printf("%q: attempt %d - %v\n", reason, attempt, httpReq.URL.String())
+		c.cfg.Metrics.observeRetry(req.Endpoint)
+		c.slogger().LogAttrs(ctx, slog.LevelWarn, "retrying request",
+			slog.String("endpoint", req.Endpoint), slog.String("host", httpReq.URL.Host),
+			slog.Int("attempt", attempt+1), slog.Duration("delay", wait), slog.Any("error", reason))
+
+		select {
+		case <-ctx.Done():
+			return nil, errors.Wrap(ctx.Err(), "error making request")
+		case <-clock.After(wait):
+		}
+	}
+}
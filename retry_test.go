@@ -0,0 +1,149 @@
+package qbittorrent
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClock returns immediately from After and records every wait
+type fakeClock struct {
+	now   time.Time
+	waits []time.Duration
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.waits = append(c.waits, d)
+	ch := make(chan time.Time, 1)
+	ch <- c.now.Add(d)
+	return ch
+}
+
+func retryAttemptWith(method, endpoint string, status int, err error) RetryAttempt {
+	a := RetryAttempt{Request: &Request{Endpoint: endpoint, Method: method}, Attempt: 1, Err: err}
+	if err == nil {
+		a.Response = &http.Response{StatusCode: status, Header: make(http.Header)}
+	}
+	return a
+}
+
+func TestBackoffPolicy_Backoff(t *testing.T) {
+	policy := &BackoffPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second, random: func() float64 { return 0.5 }}
+
+	for attempt, want := range map[int]time.Duration{
+		1:  50 * time.Millisecond,
+		2:  100 * time.Millisecond,
+		4:  400 * time.Millisecond,
+		5:  500 * time.Millisecond,
+		90: 500 * time.Millisecond,
+	} {
+		a := retryAttemptWith(http.MethodGet, "torrents/info", http.StatusBadGateway, nil)
+		a.Attempt = attempt
+		policy.MaxAttempts = attempt + 1
+
+		wait, ok := policy.NextRetry(a)
+		require.True(t, ok, "attempt %d", attempt)
+		assert.Equal(t, want, wait, "attempt %d", attempt)
+	}
+
+	a := retryAttemptWith(http.MethodGet, "torrents/info", http.StatusBadGateway, nil)
+	a.Attempt = DefaultRetryAttempts
+	_, ok := (&BackoffPolicy{}).NextRetry(a)
+	assert.False(t, ok, "attempts are exhausted")
+}
+
+func TestBackoffPolicy_RetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	policy := &BackoffPolicy{MaxRetryAfter: time.Minute}
+
+	a := retryAttemptWith(http.MethodGet, "torrents/info", http.StatusServiceUnavailable, nil)
+	a.Now = now
+
+	a.Response.Header.Set("Retry-After", "30")
+	wait, ok := policy.NextRetry(a)
+	assert.True(t, ok)
+	assert.Equal(t, 30*time.Second, wait)
+
+	a.Response.Header.Set("Retry-After", now.Add(45*time.Second).Format(http.TimeFormat))
+	wait, ok = policy.NextRetry(a)
+	assert.True(t, ok)
+	assert.Equal(t, 45*time.Second, wait)
+
+	a.Response.Header.Set("Retry-After", "3600")
+	_, ok = policy.NextRetry(a)
+	assert.False(t, ok, "waits longer than MaxRetryAfter give up")
+}
+
+func TestBackoffPolicy_Retryable(t *testing.T) {
+	dialErr := &net.OpError{Op: "dial", Net: "tcp", Err: &net.DNSError{Err: "refused"}}
+	readErr := &net.OpError{Op: "read", Net: "tcp", Err: net.ErrClosed}
+
+	for name, tc := range map[string]struct {
+		attempt RetryAttempt
+		want    bool
+	}{
+		"get bad gateway":         {retryAttemptWith(http.MethodGet, "torrents/info", http.StatusBadGateway, nil), true},
+		"get internal error":      {retryAttemptWith(http.MethodGet, "torrents/info", http.StatusInternalServerError, nil), false},
+		"get not found":           {retryAttemptWith(http.MethodGet, "torrents/info", http.StatusNotFound, nil), false},
+		"get read error":          {retryAttemptWith(http.MethodGet, "torrents/info", 0, readErr), true},
+		"get canceled":            {retryAttemptWith(http.MethodGet, "torrents/info", 0, context.Canceled), false},
+		"idempotent post":         {retryAttemptWith(http.MethodPost, "torrents/stop", http.StatusGatewayTimeout, nil), true},
+		"add gateway timeout":     {retryAttemptWith(http.MethodPost, "torrents/add", http.StatusGatewayTimeout, nil), false},
+		"add service unavailable": {retryAttemptWith(http.MethodPost, "torrents/add", http.StatusServiceUnavailable, nil), true},
+		"add read error":          {retryAttemptWith(http.MethodPost, "torrents/add", 0, readErr), false},
+		"add dial error":          {retryAttemptWith(http.MethodPost, "torrents/add", 0, dialErr), true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, ok := (&BackoffPolicy{}).NextRetry(tc.attempt)
+			assert.Equal(t, tc.want, ok)
+		})
+	}
+
+	a := retryAttemptWith(http.MethodPost, "torrents/add", http.StatusGatewayTimeout, nil)
+	_, ok := (&BackoffPolicy{RetryNonIdempotent: true}).NextRetry(a)
+	assert.True(t, ok)
+}
+
+func TestClient_RetryPolicy(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) <= 2 {
+			w.Header().Set("Retry-After", "7")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte("v5.0.0"))
+	}))
+	t.Cleanup(server.Close)
+
+	clock := &fakeClock{now: time.Now()}
+	client := NewClient(Config{Host: server.URL, APIKey: "key", Clock: clock})
+
+	version, err := client.GetAppVersion()
+	require.NoError(t, err)
+	assert.Equal(t, "v5.0.0", version)
+	assert.Equal(t, []time.Duration{7 * time.Second, 7 * time.Second}, clock.waits)
+
+	calls.Store(0)
+	clock.waits = nil
+	client = NewClient(Config{
+		Host:                  server.URL,
+		APIKey:                "key",
+		Clock:                 clock,
+		EndpointRetryPolicies: map[string]RetryPolicy{"app/version": &BackoffPolicy{MaxAttempts: 1}},
+	})
+
+	_, err = client.GetAppVersion()
+	require.Error(t, err)
+	assert.Equal(t, int32(1), calls.Load())
+	assert.Empty(t, clock.waits)
+}
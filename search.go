@@ -0,0 +1,584 @@
+package qbittorrent
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/autobrr/go-qbittorrent/errors"
+)
+
+// Search Domain Types
+
+const (
+	// SearchPluginsAll searches using every installed plugin.
+	SearchPluginsAll = "all"
+	// SearchPluginsEnabled searches using only the enabled plugins.
+	SearchPluginsEnabled = "enabled"
+	// SearchCategoryAll searches in every category a plugin supports.
+	SearchCategoryAll = "all"
+)
+
+// SearchStatus is the state of a search job as reported by qBittorrent.
+type SearchStatus string
+
+const (
+	SearchStatusRunning SearchStatus = "Running"
+	SearchStatusStopped SearchStatus = "Stopped"
+)
+
+// SearchJobStatus represents one entry of the search/status response.
+type SearchJobStatus struct {
+	ID     int          `json:"id"`
+	Status SearchStatus `json:"status"`
+	Total  int          `json:"total"`
+}
+
+// SearchResult represents a single result returned by a search plugin.
+type SearchResult struct {
+	DescrLink  string `json:"descrLink"`
+	EngineName string `json:"engineName,omitempty"` // qBittorrent 5.0+
+	FileName   string `json:"fileName"`
+	FileSize   int64  `json:"fileSize"` // -1 when the plugin does not report a size
+	FileURL    string `json:"fileUrl"`
+	NbLeechers int64  `json:"nbLeechers"`
+	NbSeeders  int64  `json:"nbSeeders"`
+	PubDate    int64  `json:"pubDate,omitempty"` // qBittorrent 5.0+
+	SiteURL    string `json:"siteUrl"`
+}
+
+// SearchResults represents the response from the search/results endpoint.
+type SearchResults struct {
+	Results []SearchResult `json:"results"`
+	Status  SearchStatus   `json:"status"`
+	Total   int            `json:"total"`
+}
+
+// SearchPlugin represents an installed search plugin.
+type SearchPlugin struct {
+	Enabled             bool                   `json:"enabled"`
+	FullName            string                 `json:"fullName"`
+	Name                string                 `json:"name"`
+	SupportedCategories []SearchPluginCategory `json:"supportedCategories"`
+	URL                 string                 `json:"url"`
+	Version             string                 `json:"version"`
+}
+
+// SearchPluginCategory is a category supported by a search plugin.
+// Before qBittorrent 4.3.0 categories were plain strings, in which case ID is left empty.
+type SearchPluginCategory struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+func (s *SearchPluginCategory) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		s.ID = ""
+		s.Name = name
+		return nil
+	}
+
+	type searchPluginCategoryAlias SearchPluginCategory
+	var alias searchPluginCategoryAlias
+	if err := json.Unmarshal(data, &alias); err != nil {
+		return errors.Wrap(err, "invalid search plugin category: %s", string(data))
+	}
+
+	*s = SearchPluginCategory(alias)
+	return nil
+}
+
+// Search Methods
+
+// StartSearch starts a new search job and returns its id.
+// plugins may contain plugin names, or one of SearchPluginsAll / SearchPluginsEnabled; empty means enabled plugins.
+// An empty category means SearchCategoryAll.
+func (c *Client) StartSearch(pattern string, plugins []string, category string) (int, error) {
+	return c.StartSearchCtx(context.Background(), pattern, plugins, category)
+}
+
+// StartSearchCtx starts a new search job with context and returns its id.
+func (c *Client) StartSearchCtx(ctx context.Context, pattern string, plugins []string, category string) (int, error) {
+	if pattern == "" {
+		return 0, ErrEmptySearchPattern
+	}
+
+	pluginsValue := SearchPluginsEnabled
+	if len(plugins) > 0 {
+		pluginsValue = strings.Join(plugins, "|")
+	}
+
+	if category == "" {
+		category = SearchCategoryAll
+	}
+
+	opts := map[string]string{
+		"pattern":  pattern,
+		"plugins":  pluginsValue,
+		"category": category,
+	}
+
+	resp, err := c.postCtx(ctx, "search/start", opts)
+	if err != nil {
+		return 0, errors.Wrap(err, "could not start search; pattern: %s", pattern)
+	}
+
+	defer drainAndClose(resp)
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		break
+	case http.StatusConflict:
+		return 0, errors.Wrap(ErrSearchTooManyJobs, "pattern: %s", pattern)
+	default:
+		return 0, errors.Wrap(ErrUnexpectedStatus, "could not start search; pattern: %s | status code: %d", pattern, resp.StatusCode)
+	}
+
+	var job struct {
+		ID int `json:"id"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&job); err != nil {
+		return 0, errors.Wrap(err, "could not unmarshal body")
+	}
+
+	return job.ID, nil
+}
+
+// StopSearch stops a running search job.
+func (c *Client) StopSearch(id int) error {
+	return c.StopSearchCtx(context.Background(), id)
+}
+
+// StopSearchCtx stops a running search job with context.
+func (c *Client) StopSearchCtx(ctx context.Context, id int) error {
+	opts := map[string]string{
+		"id": strconv.Itoa(id),
+	}
+
+	resp, err := c.postCtx(ctx, "search/stop", opts)
+	if err != nil {
+		return errors.Wrap(err, "could not stop search; id: %d", id)
+	}
+
+	defer drainAndClose(resp)
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusNoContent:
+		break
+	case http.StatusNotFound:
+		return errors.Wrap(ErrSearchJobNotFound, "id: %d", id)
+	default:
+		return errors.Wrap(ErrUnexpectedStatus, "could not stop search; id: %d | status code: %d", id, resp.StatusCode)
+	}
+
+	return nil
+}
+
+// GetSearchStatus retrieves the status of search jobs.
+// If id is 0, the status of all jobs is returned.
+func (c *Client) GetSearchStatus(id int) ([]SearchJobStatus, error) {
+	return c.GetSearchStatusCtx(context.Background(), id)
+}
+
+// GetSearchStatusCtx retrieves the status of search jobs with context.
+// If id is 0, the status of all jobs is returned.
+func (c *Client) GetSearchStatusCtx(ctx context.Context, id int) ([]SearchJobStatus, error) {
+	opts := map[string]string{}
+	if id != 0 {
+		opts["id"] = strconv.Itoa(id)
+	}
+
+	resp, err := c.getCtx(ctx, "search/status", opts)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get search status; id: %d", id)
+	}
+
+	defer drainAndClose(resp)
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		break
+	case http.StatusNotFound:
+		return nil, errors.Wrap(ErrSearchJobNotFound, "id: %d", id)
+	default:
+		return nil, errors.Wrap(ErrUnexpectedStatus, "could not get search status; id: %d | status code: %d", id, resp.StatusCode)
+	}
+
+	var statuses []SearchJobStatus
+	if err := json.NewDecoder(resp.Body).Decode(&statuses); err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal body")
+	}
+
+	return statuses, nil
+}
+
+// GetSearchResults retrieves a page of results for a search job.
+// A limit of 0 means no limit. A negative offset counts back from the end of the results.
+func (c *Client) GetSearchResults(id int, limit int, offset int) (*SearchResults, error) {
+	return c.GetSearchResultsCtx(context.Background(), id, limit, offset)
+}
+
+// GetSearchResultsCtx retrieves a page of results for a search job with context.
+func (c *Client) GetSearchResultsCtx(ctx context.Context, id int, limit int, offset int) (*SearchResults, error) {
+	opts := map[string]string{
+		"id": strconv.Itoa(id),
+	}
+	if limit > 0 {
+		opts["limit"] = strconv.Itoa(limit)
+	}
+	if offset != 0 {
+		opts["offset"] = strconv.Itoa(offset)
+	}
+
+	resp, err := c.getCtx(ctx, "search/results", opts)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get search results; id: %d", id)
+	}
+
+	defer drainAndClose(resp)
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		break
+	case http.StatusNotFound:
+		return nil, errors.Wrap(ErrSearchJobNotFound, "id: %d", id)
+	case http.StatusConflict:
+		return nil, errors.Wrap(ErrSearchInvalidOffset, "id: %d | offset: %d", id, offset)
+	default:
+		return nil, errors.Wrap(ErrUnexpectedStatus, "could not get search results; id: %d | status code: %d", id, resp.StatusCode)
+	}
+
+	var results SearchResults
+	if err := json.NewDecoder(resp.Body).Decode(&results); err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal body")
+	}
+
+	return &results, nil
+}
+
+// DeleteSearch deletes a search job and its results.
+func (c *Client) DeleteSearch(id int) error {
+	return c.DeleteSearchCtx(context.Background(), id)
+}
+
+// DeleteSearchCtx deletes a search job and its results with context.
+func (c *Client) DeleteSearchCtx(ctx context.Context, id int) error {
+	opts := map[string]string{
+		"id": strconv.Itoa(id),
+	}
+
+	resp, err := c.postCtx(ctx, "search/delete", opts)
+	if err != nil {
+		return errors.Wrap(err, "could not delete search; id: %d", id)
+	}
+
+	defer drainAndClose(resp)
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusNoContent:
+		break
+	case http.StatusNotFound:
+		return errors.Wrap(ErrSearchJobNotFound, "id: %d", id)
+	default:
+		return errors.Wrap(ErrUnexpectedStatus, "could not delete search; id: %d | status code: %d", id, resp.StatusCode)
+	}
+
+	return nil
+}
+
+// GetSearchPlugins retrieves all installed search plugins.
+func (c *Client) GetSearchPlugins() ([]SearchPlugin, error) {
+	return c.GetSearchPluginsCtx(context.Background())
+}
+
+// GetSearchPluginsCtx retrieves all installed search plugins with context.
+func (c *Client) GetSearchPluginsCtx(ctx context.Context) ([]SearchPlugin, error) {
+	resp, err := c.getCtx(ctx, "search/plugins", nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get search plugins")
+	}
+
+	defer drainAndClose(resp)
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Wrap(ErrUnexpectedStatus, "could not get search plugins; status code: %d", resp.StatusCode)
+	}
+
+	var plugins []SearchPlugin
+	if err := json.NewDecoder(resp.Body).Decode(&plugins); err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal body")
+	}
+
+	return plugins, nil
+}
+
+// InstallSearchPlugins installs search plugins from URLs or local file paths on the server.
+func (c *Client) InstallSearchPlugins(sources []string) error {
+	return c.InstallSearchPluginsCtx(context.Background(), sources)
+}
+
+// InstallSearchPluginsCtx installs search plugins with context.
+// Note: installation happens asynchronously and qBittorrent returns 200 OK even if it later fails.
+func (c *Client) InstallSearchPluginsCtx(ctx context.Context, sources []string) error {
+	if len(sources) == 0 {
+		return ErrEmptyInput
+	}
+
+	opts := map[string]string{
+		"sources": strings.Join(sources, "|"),
+	}
+
+	resp, err := c.postCtx(ctx, "search/installPlugin", opts)
+	if err != nil {
+		return errors.Wrap(err, "could not install search plugins; sources: %v", sources)
+	}
+
+	defer drainAndClose(resp)
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusNoContent:
+		break
+	default:
+		return errors.Wrap(ErrUnexpectedStatus, "could not install search plugins; sources: %v | status code: %d", sources, resp.StatusCode)
+	}
+
+	return nil
+}
+
+// UninstallSearchPlugins uninstalls search plugins by name.
+func (c *Client) UninstallSearchPlugins(names []string) error {
+	return c.UninstallSearchPluginsCtx(context.Background(), names)
+}
+
+// UninstallSearchPluginsCtx uninstalls search plugins by name with context.
+func (c *Client) UninstallSearchPluginsCtx(ctx context.Context, names []string) error {
+	if len(names) == 0 {
+		return ErrEmptyInput
+	}
+
+	opts := map[string]string{
+		"names": strings.Join(names, "|"),
+	}
+
+	resp, err := c.postCtx(ctx, "search/uninstallPlugin", opts)
+	if err != nil {
+		return errors.Wrap(err, "could not uninstall search plugins; names: %v", names)
+	}
+
+	defer drainAndClose(resp)
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusNoContent:
+		break
+	default:
+		return errors.Wrap(ErrUnexpectedStatus, "could not uninstall search plugins; names: %v | status code: %d", names, resp.StatusCode)
+	}
+
+	return nil
+}
+
+// EnableSearchPlugins enables or disables search plugins by name.
+func (c *Client) EnableSearchPlugins(names []string, enable bool) error {
+	return c.EnableSearchPluginsCtx(context.Background(), names, enable)
+}
+
+// EnableSearchPluginsCtx enables or disables search plugins by name with context.
+func (c *Client) EnableSearchPluginsCtx(ctx context.Context, names []string, enable bool) error {
+	if len(names) == 0 {
+		return ErrEmptyInput
+	}
+
+	opts := map[string]string{
+		"names":  strings.Join(names, "|"),
+		"enable": strconv.FormatBool(enable),
+	}
+
+	resp, err := c.postCtx(ctx, "search/enablePlugin", opts)
+	if err != nil {
+		return errors.Wrap(err, "could not enable search plugins; names: %v", names)
+	}
+
+	defer drainAndClose(resp)
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusNoContent:
+		break
+	default:
+		return errors.Wrap(ErrUnexpectedStatus, "could not enable search plugins; names: %v | status code: %d", names, resp.StatusCode)
+	}
+
+	return nil
+}
+
+// UpdateSearchPlugins updates all installed search plugins.
+func (c *Client) UpdateSearchPlugins() error {
+	return c.UpdateSearchPluginsCtx(context.Background())
+}
+
+// UpdateSearchPluginsCtx updates all installed search plugins with context.
+func (c *Client) UpdateSearchPluginsCtx(ctx context.Context) error {
+	resp, err := c.postCtx(ctx, "search/updatePlugins", nil)
+	if err != nil {
+		return errors.Wrap(err, "could not update search plugins")
+	}
+
+	defer drainAndClose(resp)
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusNoContent:
+		break
+	default:
+		return errors.Wrap(ErrUnexpectedStatus, "could not update search plugins; status code: %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// Search Job
+
+const (
+	searchJobPollInterval = 1 * time.Second
+	searchJobPageSize     = 100
+)
+
+// SearchJobOptions configures how a SearchJob polls for results.
+type SearchJobOptions struct {
+	// Plugins to search with; empty means enabled plugins
+	Plugins []string
+	// Category to search in; empty means all categories
+	Category string
+	// PollInterval is the delay between polls while no new results are available (default: 1s)
+	PollInterval time.Duration
+	// PageSize is the number of results fetched per request (default: 100)
+	PageSize int
+	// Buffer is the size of the results channel (default: PageSize)
+	Buffer int
+}
+
+// SearchJob tracks a running search and streams its results.
+type SearchJob struct {
+	client  *Client
+	id      int
+	options SearchJobOptions
+
+	mu  sync.Mutex
+	err error
+}
+
+// NewSearchJob starts a search and returns a job that can stream its results.
+func (c *Client) NewSearchJob(ctx context.Context, pattern string, options ...SearchJobOptions) (*SearchJob, error) {
+	var opts SearchJobOptions
+	if len(options) > 0 {
+		opts = options[0]
+	}
+
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = searchJobPollInterval
+	}
+
+	if opts.PageSize <= 0 {
+		opts.PageSize = searchJobPageSize
+	}
+
+	if opts.Buffer <= 0 {
+		opts.Buffer = opts.PageSize
+	}
+
+	id, err := c.StartSearchCtx(ctx, pattern, opts.Plugins, opts.Category)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SearchJob{
+		client:  c,
+		id:      id,
+		options: opts,
+	}, nil
+}
+
+// ID returns the qBittorrent search job id.
+func (j *SearchJob) ID() int {
+	return j.id
+}
+
+// Results polls the job until it stops and sends every result on the returned channel.
+// The channel is closed when the job has finished, an error occurred or ctx is cancelled;
+// call Err afterwards to find out why.
+func (j *SearchJob) Results(ctx context.Context) <-chan SearchResult {
+	results := make(chan SearchResult, j.options.Buffer)
+
+	go func() {
+		defer close(results)
+		j.setErr(j.poll(ctx, results))
+	}()
+
+	return results
+}
+
+// Err returns the error that ended the results stream, if any.
+func (j *SearchJob) Err() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.err
+}
+
+// Stop stops the search job.
+func (j *SearchJob) Stop(ctx context.Context) error {
+	return j.client.StopSearchCtx(ctx, j.id)
+}
+
+// Delete deletes the search job and its results from qBittorrent.
+func (j *SearchJob) Delete(ctx context.Context) error {
+	return j.client.DeleteSearchCtx(ctx, j.id)
+}
+
+func (j *SearchJob) setErr(err error) {
+	j.mu.Lock()
+	j.err = err
+	j.mu.Unlock()
+}
+
+func (j *SearchJob) poll(ctx context.Context, results chan<- SearchResult) error {
+	offset := 0
+
+	for {
+		page, err := j.client.GetSearchResultsCtx(ctx, j.id, j.options.PageSize, offset)
+		if err != nil {
+			// the retry layer hides cancellation behind its own error
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return ctxErr
+			}
+			return err
+		}
+
+		for _, result := range page.Results {
+			select {
+			case results <- result:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		offset += len(page.Results)
+
+		if page.Status == SearchStatusStopped && offset >= page.Total {
+			return nil
+		}
+
+		// a full page usually means more results are already waiting
+		if len(page.Results) == j.options.PageSize {
+			continue
+		}
+
+		select {
+		case <-time.After(j.options.PollInterval):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
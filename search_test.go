@@ -0,0 +1,209 @@
+package qbittorrent
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchPlugin_Unmarshal(t *testing.T) {
+	tests := []struct {
+		name     string
+		jsonData string
+		want     []SearchPluginCategory
+	}{
+		{
+			name: "object categories",
+			jsonData: `{
+				"enabled": true,
+				"fullName": "Legit Torrents",
+				"name": "legittorrents",
+				"supportedCategories": [{"id": "all", "name": "All categories"}, {"id": "tv", "name": "TV shows"}],
+				"url": "http://www.legittorrents.info",
+				"version": "2.3"
+			}`,
+			want: []SearchPluginCategory{{ID: "all", Name: "All categories"}, {ID: "tv", Name: "TV shows"}},
+		},
+		{
+			name: "legacy string categories",
+			jsonData: `{
+				"enabled": false,
+				"fullName": "Legit Torrents",
+				"name": "legittorrents",
+				"supportedCategories": ["All categories", "TV shows"],
+				"url": "http://www.legittorrents.info",
+				"version": "2.3"
+			}`,
+			want: []SearchPluginCategory{{Name: "All categories"}, {Name: "TV shows"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var plugin SearchPlugin
+			require.NoError(t, json.Unmarshal([]byte(tt.jsonData), &plugin))
+
+			assert.Equal(t, "legittorrents", plugin.Name)
+			assert.Equal(t, tt.want, plugin.SupportedCategories)
+		})
+	}
+}
+
+func TestSearchResults_Unmarshal(t *testing.T) {
+	jsonData := `{
+		"results": [
+			{
+				"descrLink": "http://www.legittorrents.info/index.php?page=torrent-details&id=8d5f512e1acb687029b8d7cc6c5a84dce51d7a41",
+				"fileName": "Ubuntu-10.04-32bit-NeTV.ova",
+				"fileSize": -1,
+				"fileUrl": "http://www.legittorrents.info/download.php?id=8d5f512e1acb687029b8d7cc6c5a84dce51d7a41&f=Ubuntu64.torrent",
+				"nbLeechers": 1,
+				"nbSeeders": 0,
+				"siteUrl": "http://www.legittorrents.info"
+			}
+		],
+		"status": "Running",
+		"total": 1
+	}`
+
+	var results SearchResults
+	require.NoError(t, json.Unmarshal([]byte(jsonData), &results))
+
+	assert.Equal(t, SearchStatusRunning, results.Status)
+	assert.Equal(t, 1, results.Total)
+	require.Len(t, results.Results, 1)
+	assert.Equal(t, "Ubuntu-10.04-32bit-NeTV.ova", results.Results[0].FileName)
+	assert.Equal(t, int64(-1), results.Results[0].FileSize)
+	assert.Equal(t, int64(1), results.Results[0].NbLeechers)
+}
+
+// fakeSearchServer serves search/* endpoints for a single job whose results
+// become available in batches, one batch per results request.
+type fakeSearchServer struct {
+	mu      sync.Mutex
+	batches [][]SearchResult
+	served  []SearchResult
+	polls   int
+	deleted bool
+}
+
+func (f *fakeSearchServer) handler(t *testing.T) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v2/search/start", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "ubuntu", r.FormValue("pattern"))
+		assert.Equal(t, SearchPluginsEnabled, r.FormValue("plugins"))
+		assert.Equal(t, SearchCategoryAll, r.FormValue("category"))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":12345}`))
+	})
+	mux.HandleFunc("/api/v2/search/results", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+
+		assert.Equal(t, "12345", r.URL.Query().Get("id"))
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+
+		if f.polls < len(f.batches) {
+			f.served = append(f.served, f.batches[f.polls]...)
+		}
+		f.polls++
+
+		status := SearchStatusRunning
+		if f.polls >= len(f.batches) {
+			status = SearchStatusStopped
+		}
+
+		res := SearchResults{
+			Results: f.served[offset:],
+			Status:  status,
+			Total:   len(f.served),
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(res)
+	})
+	mux.HandleFunc("/api/v2/search/delete", func(_ http.ResponseWriter, _ *http.Request) {
+		f.mu.Lock()
+		f.deleted = true
+		f.mu.Unlock()
+	})
+	return mux
+}
+
+func TestSearchJob_Results(t *testing.T) {
+	fake := &fakeSearchServer{
+		batches: [][]SearchResult{
+			{{FileName: "a"}, {FileName: "b"}},
+			{},
+			{{FileName: "c"}},
+		},
+	}
+
+	server := httptest.NewServer(fake.handler(t))
+	defer server.Close()
+
+	client := NewClient(Config{Host: server.URL})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	job, err := client.NewSearchJob(ctx, "ubuntu", SearchJobOptions{PollInterval: time.Millisecond})
+	require.NoError(t, err)
+	assert.Equal(t, 12345, job.ID())
+
+	var names []string
+	for result := range job.Results(ctx) {
+		names = append(names, result.FileName)
+	}
+
+	require.NoError(t, job.Err())
+	assert.Equal(t, []string{"a", "b", "c"}, names)
+
+	require.NoError(t, job.Delete(ctx))
+	assert.True(t, fake.deleted)
+}
+
+func TestSearchJob_ResultsCancelled(t *testing.T) {
+	fake := &fakeSearchServer{
+		batches: make([][]SearchResult, 1000),
+	}
+
+	server := httptest.NewServer(fake.handler(t))
+	defer server.Close()
+
+	client := NewClient(Config{Host: server.URL})
+
+	job, err := client.NewSearchJob(context.Background(), "ubuntu", SearchJobOptions{PollInterval: 10 * time.Millisecond})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	for range job.Results(ctx) {
+	}
+
+	assert.ErrorIs(t, job.Err(), context.DeadlineExceeded)
+}
+
+func TestClient_GetSearchResults_NotFound(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v2/search/results", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := NewClient(Config{Host: server.URL})
+
+	_, err := client.GetSearchResults(1, 0, 0)
+	assert.ErrorIs(t, err, ErrSearchJobNotFound)
+}
@@ -3,8 +3,67 @@ package qbittorrent
 import (
 	"slices"
 	"testing"
+
+	"github.com/autobrr/go-qbittorrent/errors"
 )
 
+func TestTorrentSortKeys(t *testing.T) {
+	torrents := []Torrent{
+		{Name: "Echo", Hash: "hash5", State: TorrentStateUploading, Ratio: 1},
+		{Name: "Delta", Hash: "hash4", State: TorrentStateDownloading, Ratio: 0.5},
+		{Name: "Charlie", Hash: "hash3", State: TorrentStateUploading, Ratio: 2},
+		{Name: "Bravo", Hash: "hash2", State: TorrentStateUploading, Ratio: 1},
+		{Name: "Alpha", Hash: "hash1", State: TorrentStateDownloading, Ratio: 0.5},
+	}
+
+	keys, err := ParseTorrentSort("state asc, ratio DESC, name")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	test := slices.Clone(torrents)
+	applyTorrentSortKeys(test, keys)
+
+	expected := []string{"Alpha", "Delta", "Charlie", "Bravo", "Echo"}
+	for i, torrent := range test {
+		if torrent.Name != expected[i] {
+			t.Errorf("Expected %s at position %d, got %s", expected[i], i, torrent.Name)
+		}
+	}
+
+	t.Run("filter options prefer sort keys", func(t *testing.T) {
+		result := applyTorrentFilterOptions(slices.Clone(torrents), TorrentFilterOptions{
+			Sort:     "name",
+			SortKeys: []TorrentSortKey{{Field: "ratio", Desc: true}, {Field: "hash"}},
+			Limit:    2,
+		})
+
+		if len(result) != 2 || result[0].Name != "Charlie" || result[1].Name != "Bravo" {
+			t.Errorf("Expected Charlie then Bravo, got %v", torrentHashes(result))
+		}
+	})
+
+	t.Run("slice fields sort by length", func(t *testing.T) {
+		test := []Torrent{
+			{Hash: "b", Trackers: make([]TorrentTracker, 2)},
+			{Hash: "a", Trackers: make([]TorrentTracker, 1)},
+		}
+		applyTorrentSortKeys(test, []TorrentSortKey{{Field: "trackers"}})
+
+		if test[0].Hash != "a" {
+			t.Errorf("Expected torrent with fewer trackers first, got %s", test[0].Hash)
+		}
+	})
+}
+
+func TestParseTorrentSort_Errors(t *testing.T) {
+	for _, spec := range []string{"", "name,", "nope", "default", "name sideways", "name asc desc"} {
+		if _, err := ParseTorrentSort(spec); !errors.Is(err, ErrInvalidSortSpec) {
+			t.Errorf("%q: expected ErrInvalidSortSpec, got %v", spec, err)
+		}
+	}
+}
+
 func TestTorrentSorting(t *testing.T) {
 	// Create test torrents with different values
 	torrents := []Torrent{
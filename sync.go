@@ -4,11 +4,14 @@ import (
 	"context"
 	"maps"
 	"math/rand"
+	"os"
 	"slices"
 	"sync"
 	"time"
 
 	"golang.org/x/sync/singleflight"
+
+	"github.com/autobrr/go-qbittorrent/errors"
 )
 
 // SyncManager manages synchronization of MainData updates and provides
@@ -21,12 +24,16 @@ type SyncManager struct {
 	lastSuccessfulSync time.Time
 	lastSyncDuration   time.Duration
 	lastError          error
+	warmSyncing        bool
 	client             *Client
 	trackerManager     *TrackerManager
 	syncGroup          singleflight.Group
 	options            SyncOptions
 	allTorrents        []Torrent
 	resultPool         sync.Pool
+	subMu              sync.Mutex
+	subscribers        map[*syncSubscriber]struct{}
+	index              *torrentIndex
 }
 
 // SyncOptions configures the behavior of the sync manager
@@ -49,6 +56,11 @@ type SyncOptions struct {
 	OnError func(error)
 	// RetainRemovedData keeps removed items for one sync cycle for comparison
 	RetainRemovedData bool
+	// SnapshotPath enables warm restarts: Start loads the snapshot at this path and
+	// the data is saved back to it every SnapshotInterval and when the Start context is done
+	SnapshotPath string
+	// SnapshotInterval is the interval between snapshot saves; 0 only saves on shutdown
+	SnapshotInterval time.Duration
 }
 
 // DefaultSyncOptions returns sensible default options
@@ -96,10 +108,30 @@ func (sm *SyncManager) Trackers() *TrackerManager {
 	return sm.trackerManager
 }
 
-// Start initializes the sync manager and optionally starts auto-sync
+// Start initializes the sync manager and optionally starts auto-sync.
+// If SnapshotPath holds a valid snapshot, it is served immediately while the
+// initial full sync runs in the background and reports failures through OnError.
 func (sm *SyncManager) Start(ctx context.Context) error {
+	warm := false
+	if sm.options.SnapshotPath != "" {
+		if _, err := sm.LoadSnapshotFile(sm.options.SnapshotPath); err == nil {
+			warm = true
+		} else if !errors.Is(err, os.ErrNotExist) && sm.options.OnError != nil {
+			sm.options.OnError(err)
+		}
+
+		go sm.snapshotLoop(ctx)
+	}
+
 	// Perform initial full sync
-	if err := sm.Sync(ctx); err != nil {
+	if warm {
+		// readers get the snapshot instead of waiting for this sync to refresh it
+		sm.mu.Lock()
+		sm.warmSyncing = true
+		sm.mu.Unlock()
+
+		go sm.Sync(ctx)
+	} else if err := sm.Sync(ctx); err != nil {
 		return err
 	}
 
@@ -122,13 +154,30 @@ func (sm *SyncManager) Sync(ctx context.Context) error {
 	return err
 }
 
-// doSync performs the actual sync operation (singleflight-compatible signature)
+// doSync performs the actual sync operation (singleflight-compatible signature).
+// The request is made without holding sm.mu, so readers keep being served the
+// current data until the response is applied.
 func (sm *SyncManager) doSync(ctx context.Context) (interface{}, error) {
 	startTime := time.Now()
-	var err error = nil
+
+	sm.mu.RLock()
+	var rid int64
+	if sm.data != nil {
+		rid = int64(sm.data.Rid)
+	}
+	sm.mu.RUnlock()
+
+	source, rawData, err := sm.client.SyncMainDataCtxWithRaw(ctx, rid)
+
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	prevRid := sm.rid
+	var rec *syncEventRecorder
 
 	defer func() {
 		sm.lastSyncDuration = time.Since(startTime)
+		sm.observeSync(err, prevRid, rec)
 		sm.lastSync = time.Now()
 		// lastSync records every attempt (used internally to pace syncs), but
 		// lastSuccessfulSync only advances when the data actually updated, so
@@ -137,26 +186,33 @@ func (sm *SyncManager) doSync(ctx context.Context) (interface{}, error) {
 			sm.lastSuccessfulSync = sm.lastSync
 		}
 		sm.lastError = err
-		sm.mu.Unlock()
+		sm.warmSyncing = false
 	}()
 
-	// Initialize data if needed
-	if sm.data == nil {
-		sm.data = &MainData{}
-	}
-
-	sm.mu.Lock()
-	if err = sm.data.Update(ctx, sm.client); err != nil {
+	if err != nil {
 		if sm.options.OnError != nil {
 			sm.options.OnError(err)
 		}
 		return nil, err
 	}
 
+	// Initialize data if needed
+	if sm.data == nil {
+		sm.data = &MainData{}
+	}
+
+	// Only compute events when someone is listening
+	rec = &syncEventRecorder{emit: sm.hasSubscribers()}
+
+	sm.data.apply(source, rawData, rec)
+
 	sm.rid = sm.data.Rid
 	// Update cached torrent slice
 	sm.updateAllTorrents()
 
+	sm.updateIndex(rec)
+	sm.publish(rec.events)
+
 	// Call update callback if set
 	if sm.options.OnUpdate != nil {
 		sm.options.OnUpdate(sm.copyMainData(sm.data))
@@ -179,6 +235,12 @@ func (sm *SyncManager) ensureFreshData() {
 	sm.mu.RLock()
 	t := time.Now()
 
+	if sm.warmSyncing {
+		// the snapshot is served until the initial sync of a warm start has finished
+		sm.mu.RUnlock()
+		return
+	}
+
 	if t.Before(sm.lastSync.Add(5 * time.Millisecond)) {
 		// We just checked freshness, no need to check again
 		sm.mu.RUnlock()
@@ -295,11 +357,7 @@ func (sm *SyncManager) GetTorrentsUnchecked(options TorrentFilterOptions) []Torr
 		resultBuffer = make([]Torrent, 0, length)
 	}
 
-	for _, torrent := range sm.allTorrents {
-		if matchesTorrentFilter(torrent, options) {
-			resultBuffer = append(resultBuffer, torrent)
-		}
-	}
+	resultBuffer = sm.collectMatchingTorrents(resultBuffer, options)
 
 	filtered := applyTorrentFilterOptions(resultBuffer, options)
 	result := slices.Clone(filtered)
@@ -307,6 +365,41 @@ func (sm *SyncManager) GetTorrentsUnchecked(options TorrentFilterOptions) []Torr
 	return result
 }
 
+// collectMatchingTorrents appends every torrent matching options to dst. Hash lookups and the
+// secondary indexes keep this proportional to the number of candidates instead of all torrents.
+// Must be called with sm.mu held.
+func (sm *SyncManager) collectMatchingTorrents(dst []Torrent, options TorrentFilterOptions) []Torrent {
+	if len(options.Hashes) > 0 {
+		for _, hash := range removeDuplicateStrings(options.Hashes) {
+			if torrent, ok := sm.data.Torrents[hash]; ok && matchesTorrentFilter(torrent, options) {
+				dst = append(dst, torrent)
+			}
+		}
+		return dst
+	}
+
+	if sm.index != nil {
+		if sets, ok := sm.index.candidates(options); ok {
+			for _, set := range sets {
+				for hash := range set {
+					if torrent, ok := sm.data.Torrents[hash]; ok && matchesTorrentFilter(torrent, options) {
+						dst = append(dst, torrent)
+					}
+				}
+			}
+			return dst
+		}
+	}
+
+	for _, torrent := range sm.allTorrents {
+		if matchesTorrentFilter(torrent, options) {
+			dst = append(dst, torrent)
+		}
+	}
+
+	return dst
+}
+
 // GetTorrentMap returns a filtered map of torrents keyed by hash
 func (sm *SyncManager) GetTorrentMap(options TorrentFilterOptions) map[string]Torrent {
 	torrents := sm.GetTorrents(options)
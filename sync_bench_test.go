@@ -1,6 +1,7 @@
 package qbittorrent
 
 import (
+	"fmt"
 	"testing"
 	"time"
 )
@@ -109,3 +110,96 @@ func BenchmarkSyncManager_MultipleSequentialGetsUnchecked(b *testing.B) {
 		_ = syncManager.GetCategoriesUnchecked()
 	}
 }
+
+// createLargeBenchSyncManager builds a sync manager with n torrents spread over
+// 50 categories, 100 tags and 20 tracker hosts, with its secondary indexes built.
+func createLargeBenchSyncManager(n int) *SyncManager {
+	states := []TorrentState{TorrentStateUploading, TorrentStateStalledUp, TorrentStateDownloading, TorrentStateStoppedUp, TorrentStateQueuedDl}
+
+	torrents := make(map[string]Torrent, n)
+	for i := 0; i < n; i++ {
+		hash := fmt.Sprintf("%040x", i)
+		torrents[hash] = Torrent{
+			Hash:     hash,
+			Name:     fmt.Sprintf("Torrent %d", i),
+			Category: fmt.Sprintf("category-%d", i%50),
+			Tags:     fmt.Sprintf("tag-%d, tag-%d", i%100, (i+1)%100),
+			State:    states[i%len(states)],
+			Tracker:  fmt.Sprintf("https://tracker-%d.example/announce", i%20),
+			SavePath: fmt.Sprintf("/data/%d", i%50),
+		}
+	}
+
+	sm := createBenchSyncManager()
+	sm.data.Torrents = torrents
+	sm.updateAllTorrents()
+	sm.rebuildIndex()
+
+	return sm
+}
+
+var benchIndexQueries = map[string]TorrentFilterOptions{
+	"category":     {Category: "category-7"},
+	"tag":          {Tag: "tag-42"},
+	"state":        {Filter: TorrentFilterDownloading},
+	"category+tag": {Category: "category-7", Tag: "tag-7"},
+}
+
+// Benchmark filtered queries served from the secondary indexes
+func BenchmarkSyncManager_GetTorrentsUnchecked_Indexed(b *testing.B) {
+	syncManager := createLargeBenchSyncManager(20000)
+
+	for name, options := range benchIndexQueries {
+		b.Run(name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_ = syncManager.GetTorrentsUnchecked(options)
+			}
+		})
+	}
+}
+
+// Benchmark the same queries with the indexes disabled, forcing a linear scan
+func BenchmarkSyncManager_GetTorrentsUnchecked_LinearScan(b *testing.B) {
+	syncManager := createLargeBenchSyncManager(20000)
+	syncManager.index = nil
+
+	for name, options := range benchIndexQueries {
+		b.Run(name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_ = syncManager.GetTorrentsUnchecked(options)
+			}
+		})
+	}
+}
+
+// Benchmark tracker host lookups, which have no linear equivalent in TorrentFilterOptions
+func BenchmarkSyncManager_GetTorrentsByTrackerHostUnchecked(b *testing.B) {
+	syncManager := createLargeBenchSyncManager(20000)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = syncManager.GetTorrentsByTrackerHostUnchecked("tracker-3.example")
+	}
+}
+
+// Benchmark the cost of keeping the indexes up to date for a typical partial update
+func BenchmarkSyncManager_UpdateIndexPartial(b *testing.B) {
+	syncManager := createLargeBenchSyncManager(20000)
+
+	rec := &syncEventRecorder{}
+	for hash := range syncManager.data.Torrents {
+		rec.touch(hash)
+		if len(rec.touched) == 200 {
+			break
+		}
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		syncManager.updateIndex(rec)
+	}
+}
@@ -0,0 +1,312 @@
+package qbittorrent
+
+import (
+	"context"
+	"slices"
+)
+
+// SyncEvent is a typed change computed from a MainData update.
+// It is one of TorrentAdded, TorrentRemoved, TorrentStateChanged, TorrentCompleted,
+// TorrentFieldChanged, CategoryAdded, CategoryRemoved, TagAdded or TagRemoved.
+type SyncEvent interface {
+	syncEvent()
+}
+
+// TorrentAdded is emitted when a torrent appears in the sync data.
+type TorrentAdded struct {
+	Torrent Torrent
+}
+
+// TorrentRemoved is emitted when a torrent is removed. Torrent holds its last known state.
+type TorrentRemoved struct {
+	Hash    string
+	Torrent Torrent
+}
+
+// TorrentStateChanged is emitted when a torrent's state changes.
+type TorrentStateChanged struct {
+	Hash string
+	From TorrentState
+	To   TorrentState
+}
+
+// TorrentCompleted is emitted when a torrent's progress reaches 100%.
+type TorrentCompleted struct {
+	Torrent Torrent
+}
+
+// TorrentFieldChanged is emitted for every field present in a partial torrent update.
+// Field is the JSON key and Value the raw value sent by qBittorrent.
+// Full updates do not emit field changes.
+type TorrentFieldChanged struct {
+	Hash  string
+	Field string
+	Value interface{}
+}
+
+// CategoryAdded is emitted when a category is created.
+type CategoryAdded struct {
+	Category Category
+}
+
+// CategoryRemoved is emitted when a category is deleted.
+type CategoryRemoved struct {
+	Name string
+}
+
+// TagAdded is emitted when a tag is created.
+type TagAdded struct {
+	Tag string
+}
+
+// TagRemoved is emitted when a tag is deleted.
+type TagRemoved struct {
+	Tag string
+}
+
+func (TorrentAdded) syncEvent()        {}
+func (TorrentRemoved) syncEvent()      {}
+func (TorrentStateChanged) syncEvent() {}
+func (TorrentCompleted) syncEvent()    {}
+func (TorrentFieldChanged) syncEvent() {}
+func (CategoryAdded) syncEvent()       {}
+func (CategoryRemoved) syncEvent()     {}
+func (TagAdded) syncEvent()            {}
+func (TagRemoved) syncEvent()          {}
+
+// SyncEventDropPolicy decides which event is discarded when a subscriber's buffer is full.
+type SyncEventDropPolicy int
+
+const (
+	// DropNewest discards the incoming event
+	DropNewest SyncEventDropPolicy = iota
+	// DropOldest discards the oldest buffered event to make room for the incoming one
+	DropOldest
+)
+
+// SubscribeOptions configures a SyncManager subscription
+type SubscribeOptions struct {
+	// BufferSize is the number of events buffered for the subscriber (default: 256)
+	BufferSize int
+	// DropPolicy decides which event is discarded when the buffer is full (default: DropNewest)
+	DropPolicy SyncEventDropPolicy
+	// OnDrop is called with every discarded event
+	OnDrop func(SyncEvent)
+}
+
+type syncSubscriber struct {
+	ch      chan SyncEvent
+	options SubscribeOptions
+}
+
+// Subscribe returns a channel of events computed from every sync after the call.
+// The channel is closed once ctx is done. Slow subscribers never block syncing;
+// when the buffer is full events are dropped according to the DropPolicy.
+func (sm *SyncManager) Subscribe(ctx context.Context, options ...SubscribeOptions) <-chan SyncEvent {
+	var opts SubscribeOptions
+	if len(options) > 0 {
+		opts = options[0]
+	}
+
+	if opts.BufferSize <= 0 {
+		opts.BufferSize = 256
+	}
+
+	sub := &syncSubscriber{
+		ch:      make(chan SyncEvent, opts.BufferSize),
+		options: opts,
+	}
+
+	sm.subMu.Lock()
+	if sm.subscribers == nil {
+		sm.subscribers = make(map[*syncSubscriber]struct{})
+	}
+	sm.subscribers[sub] = struct{}{}
+	sm.subMu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+
+		sm.subMu.Lock()
+		delete(sm.subscribers, sub)
+		close(sub.ch)
+		sm.subMu.Unlock()
+	}()
+
+	return sub.ch
+}
+
+func (sm *SyncManager) hasSubscribers() bool {
+	sm.subMu.Lock()
+	defer sm.subMu.Unlock()
+
+	return len(sm.subscribers) > 0
+}
+
+// publish delivers events to every subscriber without blocking. OnDrop callbacks run after
+// subMu is released, so they may subscribe or wait for a subscription to end.
+func (sm *SyncManager) publish(events []SyncEvent) {
+	if len(events) == 0 {
+		return
+	}
+
+	var drops []droppedSyncEvent
+
+	sm.subMu.Lock()
+	for sub := range sm.subscribers {
+		for _, event := range events {
+			drops = sub.send(event, drops)
+		}
+	}
+	sm.subMu.Unlock()
+
+	for _, drop := range drops {
+		drop.onDrop(drop.event)
+	}
+}
+
+type droppedSyncEvent struct {
+	onDrop func(SyncEvent)
+	event  SyncEvent
+}
+
+// send delivers event, appending any discarded event to drops if the subscriber has an OnDrop
+func (s *syncSubscriber) send(event SyncEvent, drops []droppedSyncEvent) []droppedSyncEvent {
+	select {
+	case s.ch <- event:
+		return drops
+	default:
+	}
+
+	if s.options.DropPolicy == DropOldest {
+		select {
+		case dropped := <-s.ch:
+			drops = s.dropped(dropped, drops)
+		default:
+		}
+
+		select {
+		case s.ch <- event:
+			return drops
+		default:
+		}
+	}
+
+	return s.dropped(event, drops)
+}
+
+func (s *syncSubscriber) dropped(event SyncEvent, drops []droppedSyncEvent) []droppedSyncEvent {
+	if s.options.OnDrop == nil {
+		return drops
+	}
+	return append(drops, droppedSyncEvent{onDrop: s.options.OnDrop, event: event})
+}
+
+// syncEventRecorder collects changes while MainData is updated. A nil recorder records nothing.
+// Touched hashes are always tracked; events are only built when emit is set.
+type syncEventRecorder struct {
+	emit       bool
+	events     []SyncEvent
+	fullUpdate bool
+	touched    []string
+}
+
+func (r *syncEventRecorder) emitting() bool {
+	return r != nil && r.emit
+}
+
+func (r *syncEventRecorder) add(event SyncEvent) {
+	if r.emitting() {
+		r.events = append(r.events, event)
+	}
+}
+
+// touch records that the torrent with hash was added, updated or removed
+func (r *syncEventRecorder) touch(hash string) {
+	if r != nil {
+		r.touched = append(r.touched, hash)
+	}
+}
+
+// full records that the data was replaced by a full update
+func (r *syncEventRecorder) full() {
+	if r != nil {
+		r.fullUpdate = true
+	}
+}
+
+// torrentChanged records the state and completion events between prev and next
+func (r *syncEventRecorder) torrentChanged(prev, next Torrent) {
+	if !r.emitting() {
+		return
+	}
+
+	if prev.State != next.State {
+		r.add(TorrentStateChanged{Hash: next.Hash, From: prev.State, To: next.State})
+	}
+
+	if prev.Progress < 1 && next.Progress >= 1 {
+		r.add(TorrentCompleted{Torrent: next})
+	}
+}
+
+// diff records the events between a previous and a fully replaced MainData
+func (r *syncEventRecorder) diff(prev, next *MainData) {
+	if !r.emitting() {
+		return
+	}
+
+	for hash, torrent := range next.Torrents {
+		old, exists := prev.Torrents[hash]
+		if !exists {
+			r.add(TorrentAdded{Torrent: torrent})
+			continue
+		}
+		r.torrentChanged(old, torrent)
+	}
+
+	for hash, torrent := range prev.Torrents {
+		if _, exists := next.Torrents[hash]; !exists {
+			r.add(TorrentRemoved{Hash: hash, Torrent: torrent})
+		}
+	}
+
+	for name, category := range next.Categories {
+		if _, exists := prev.Categories[name]; !exists {
+			r.add(CategoryAdded{Category: category})
+		}
+	}
+
+	for name := range prev.Categories {
+		if _, exists := next.Categories[name]; !exists {
+			r.add(CategoryRemoved{Name: name})
+		}
+	}
+
+	r.tagsAdded(prev.Tags, next.Tags)
+	r.tagsRemoved(prev.Tags, next.Tags)
+}
+
+func (r *syncEventRecorder) tagsAdded(existing, added []string) {
+	if !r.emitting() {
+		return
+	}
+
+	for _, tag := range added {
+		if !slices.Contains(existing, tag) {
+			r.add(TagAdded{Tag: tag})
+		}
+	}
+}
+
+func (r *syncEventRecorder) tagsRemoved(existing, remaining []string) {
+	if !r.emitting() {
+		return
+	}
+
+	for _, tag := range existing {
+		if !slices.Contains(remaining, tag) {
+			r.add(TagRemoved{Tag: tag})
+		}
+	}
+}
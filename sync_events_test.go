@@ -0,0 +1,162 @@
+package qbittorrent
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newSyncManagerWithBodies returns a SyncManager whose maindata requests are answered
+// with the given bodies in order; the last body is repeated once exhausted.
+func newSyncManagerWithBodies(bodies ...string) *SyncManager {
+	calls := 0
+	return newSyncManagerWithTransport(func(req *http.Request) (*http.Response, error) {
+		body := bodies[min(calls, len(bodies)-1)]
+		calls++
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(bytes.NewReader([]byte(body))),
+			Header:     make(http.Header),
+		}, nil
+	})
+}
+
+func drainEvents(ch <-chan SyncEvent) []SyncEvent {
+	var events []SyncEvent
+	for {
+		select {
+		case event := <-ch:
+			events = append(events, event)
+		default:
+			return events
+		}
+	}
+}
+
+func TestSyncManager_Subscribe(t *testing.T) {
+	sm := newSyncManagerWithBodies(
+		`{"rid":1,"full_update":true,"torrents":{"aaa":{"name":"a","state":"downloading","progress":0.5}},"categories":{"tv":{"savePath":"/tv"}},"tags":["old"],"server_state":{}}`,
+		`{"rid":2,"torrents":{"aaa":{"state":"uploading","progress":1},"bbb":{"name":"b","state":"stalledDL"}},"categories":{"movies":{"savePath":"/movies"}},"categories_removed":["tv"],"tags":["new"],"tags_removed":["old"]}`,
+		`{"rid":3,"torrents_removed":["bbb"]}`,
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events := sm.Subscribe(ctx)
+
+	require.NoError(t, sm.Sync(ctx))
+	initial := drainEvents(events)
+	require.Len(t, initial, 3)
+	assert.Contains(t, initial, TagAdded{Tag: "old"})
+
+	require.NoError(t, sm.Sync(ctx))
+	partial := drainEvents(events)
+
+	assert.Contains(t, partial, TorrentStateChanged{Hash: "aaa", From: TorrentStateDownloading, To: TorrentStateUploading})
+	assert.Contains(t, partial, TorrentFieldChanged{Hash: "aaa", Field: "state", Value: "uploading"})
+	assert.Contains(t, partial, CategoryRemoved{Name: "tv"})
+	assert.Contains(t, partial, TagAdded{Tag: "new"})
+	assert.Contains(t, partial, TagRemoved{Tag: "old"})
+
+	var completed, added, categoryAdded bool
+	for _, event := range partial {
+		switch e := event.(type) {
+		case TorrentCompleted:
+			completed = e.Torrent.Hash == "aaa"
+		case TorrentAdded:
+			added = e.Torrent.Hash == "bbb" && e.Torrent.Name == "b"
+		case CategoryAdded:
+			categoryAdded = e.Category.Name == "movies"
+		}
+	}
+	assert.True(t, completed, "expected TorrentCompleted for aaa")
+	assert.True(t, added, "expected TorrentAdded for bbb")
+	assert.True(t, categoryAdded, "expected CategoryAdded for movies")
+
+	require.NoError(t, sm.Sync(ctx))
+	removed := drainEvents(events)
+	require.Len(t, removed, 1)
+	assert.Equal(t, "bbb", removed[0].(TorrentRemoved).Hash)
+
+	cancel()
+	for range events {
+		// wait for the channel to be closed
+	}
+}
+
+func TestSyncManager_SubscribeDropPolicy(t *testing.T) {
+	body := `{"rid":1,"full_update":true,"torrents":{"aaa":{},"bbb":{},"ccc":{}},"categories":{},"tags":[],"server_state":{}}`
+
+	for _, tc := range []struct {
+		name   string
+		policy SyncEventDropPolicy
+	}{
+		{name: "drop newest", policy: DropNewest},
+		{name: "drop oldest", policy: DropOldest},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			sm := newSyncManagerWithBodies(body)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			var dropped []SyncEvent
+			events := sm.Subscribe(ctx, SubscribeOptions{
+				BufferSize: 1,
+				DropPolicy: tc.policy,
+				OnDrop:     func(e SyncEvent) { dropped = append(dropped, e) },
+			})
+
+			require.NoError(t, sm.Sync(ctx))
+
+			kept := drainEvents(events)
+			require.Len(t, kept, 1)
+			require.Len(t, dropped, 2)
+			assert.NotContains(t, dropped, kept[0])
+		})
+	}
+}
+
+func TestSyncManager_SubscribeOnDropReentrant(t *testing.T) {
+	sm := newSyncManagerWithBodies(`{"rid":1,"full_update":true,"torrents":{"aaa":{},"bbb":{}},"categories":{},"tags":[],"server_state":{}}`)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var resubscribed []<-chan SyncEvent
+	sm.Subscribe(ctx, SubscribeOptions{
+		BufferSize: 1,
+		OnDrop: func(SyncEvent) {
+			resubscribed = append(resubscribed, sm.Subscribe(ctx))
+		},
+	})
+
+	done := make(chan error, 1)
+	go func() { done <- sm.Sync(ctx) }()
+
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("OnDrop calling Subscribe deadlocked the sync")
+	}
+	assert.Len(t, resubscribed, 1)
+}
+
+func TestMainData_UpdateWithRawDataWithoutSubscribers(t *testing.T) {
+	dest := &MainData{Torrents: map[string]Torrent{"aaa": {Hash: "aaa", State: TorrentStateDownloading}}}
+	raw := map[string]interface{}{
+		"torrents": map[string]interface{}{"aaa": map[string]interface{}{"state": "uploading"}},
+	}
+
+	// a nil recorder must be safe
+	dest.updateWithRawData(raw, &MainData{Rid: 1}, nil)
+	assert.Equal(t, TorrentStateUploading, dest.Torrents["aaa"].State)
+}
@@ -0,0 +1,241 @@
+package qbittorrent
+
+import (
+	"net/url"
+	"strings"
+)
+
+// torrentIndexKeys are the indexed values of a single torrent
+type torrentIndexKeys struct {
+	category    string
+	tags        []string
+	trackerHost string
+	state       TorrentState
+	savePath    string
+}
+
+// torrentIndex holds secondary indexes over the synced torrents, each mapping a key to the
+// set of hashes with that key. It is updated incrementally from the hashes touched by a sync.
+type torrentIndex struct {
+	keys          map[string]torrentIndexKeys
+	byCategory    map[string]map[string]struct{}
+	byTag         map[string]map[string]struct{}
+	byTrackerHost map[string]map[string]struct{}
+	byState       map[TorrentState]map[string]struct{}
+	bySavePath    map[string]map[string]struct{}
+}
+
+func newTorrentIndex(torrents map[string]Torrent) *torrentIndex {
+	idx := &torrentIndex{
+		keys:          make(map[string]torrentIndexKeys, len(torrents)),
+		byCategory:    make(map[string]map[string]struct{}),
+		byTag:         make(map[string]map[string]struct{}),
+		byTrackerHost: make(map[string]map[string]struct{}),
+		byState:       make(map[TorrentState]map[string]struct{}),
+		bySavePath:    make(map[string]map[string]struct{}),
+	}
+
+	for hash, torrent := range torrents {
+		idx.set(hash, torrent)
+	}
+
+	return idx
+}
+
+func indexKeysFor(torrent Torrent) torrentIndexKeys {
+	keys := torrentIndexKeys{
+		category:    torrent.Category,
+		trackerHost: trackerHostname(torrent.Tracker),
+		state:       torrent.State,
+		savePath:    torrent.SavePath,
+	}
+
+	if torrent.Tags != "" {
+		for tag := range strings.SplitSeq(torrent.Tags, ",") {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				keys.tags = append(keys.tags, tag)
+			}
+		}
+	}
+
+	return keys
+}
+
+// trackerHostname returns the hostname of a tracker URL, or "" if it has none
+func trackerHostname(tracker string) string {
+	if tracker == "" {
+		return ""
+	}
+
+	u, err := url.Parse(tracker)
+	if err != nil {
+		return ""
+	}
+
+	return strings.ToLower(u.Hostname())
+}
+
+// set indexes torrent under hash, replacing whatever was indexed for it before
+func (idx *torrentIndex) set(hash string, torrent Torrent) {
+	idx.remove(hash)
+
+	keys := indexKeysFor(torrent)
+	idx.keys[hash] = keys
+
+	addToSet(idx.byCategory, keys.category, hash)
+	addToSet(idx.byTrackerHost, keys.trackerHost, hash)
+	addToSet(idx.byState, keys.state, hash)
+	addToSet(idx.bySavePath, keys.savePath, hash)
+	for _, tag := range keys.tags {
+		addToSet(idx.byTag, tag, hash)
+	}
+}
+
+// remove drops hash from every index
+func (idx *torrentIndex) remove(hash string) {
+	keys, ok := idx.keys[hash]
+	if !ok {
+		return
+	}
+	delete(idx.keys, hash)
+
+	removeFromSet(idx.byCategory, keys.category, hash)
+	removeFromSet(idx.byTrackerHost, keys.trackerHost, hash)
+	removeFromSet(idx.byState, keys.state, hash)
+	removeFromSet(idx.bySavePath, keys.savePath, hash)
+	for _, tag := range keys.tags {
+		removeFromSet(idx.byTag, tag, hash)
+	}
+}
+
+// candidates returns the smallest collection of hash sets that together contain every torrent
+// matching the category, tag and state filter in options. ok is false when no index applies.
+func (idx *torrentIndex) candidates(options TorrentFilterOptions) (sets []map[string]struct{}, ok bool) {
+	best := -1
+
+	consider := func(candidate []map[string]struct{}) {
+		size := 0
+		for _, set := range candidate {
+			size += len(set)
+		}
+		if best < 0 || size < best {
+			best = size
+			sets = candidate
+		}
+	}
+
+	if options.Category != "" {
+		consider([]map[string]struct{}{idx.byCategory[options.Category]})
+	}
+
+	if tag := strings.TrimSpace(options.Tag); tag != "" {
+		consider([]map[string]struct{}{idx.byTag[tag]})
+	}
+
+	if options.Filter != "" && options.Filter != TorrentFilterAll {
+		var states []map[string]struct{}
+		for state, set := range idx.byState {
+			if matchesStateFilter(state, options.Filter) {
+				states = append(states, set)
+			}
+		}
+		consider(states)
+	}
+
+	return sets, best >= 0
+}
+
+func addToSet[K comparable](index map[K]map[string]struct{}, key K, hash string) {
+	set, ok := index[key]
+	if !ok {
+		set = make(map[string]struct{})
+		index[key] = set
+	}
+	set[hash] = struct{}{}
+}
+
+func removeFromSet[K comparable](index map[K]map[string]struct{}, key K, hash string) {
+	set, ok := index[key]
+	if !ok {
+		return
+	}
+	delete(set, hash)
+	if len(set) == 0 {
+		delete(index, key)
+	}
+}
+
+// updateIndex applies the changes recorded during a sync to the secondary indexes.
+// Must be called with sm.mu held for writing.
+func (sm *SyncManager) updateIndex(rec *syncEventRecorder) {
+	if sm.index == nil || rec.fullUpdate {
+		sm.rebuildIndex()
+		return
+	}
+
+	for _, hash := range rec.touched {
+		if torrent, ok := sm.data.Torrents[hash]; ok {
+			sm.index.set(hash, torrent)
+		} else {
+			sm.index.remove(hash)
+		}
+	}
+}
+
+// rebuildIndex indexes every torrent from scratch. Must be called with sm.mu held for writing.
+func (sm *SyncManager) rebuildIndex() {
+	sm.index = newTorrentIndex(sm.data.Torrents)
+}
+
+// GetTorrentsByTrackerHost returns the torrents whose current tracker is on host
+func (sm *SyncManager) GetTorrentsByTrackerHost(host string) []Torrent {
+	sm.ensureFreshData()
+	return sm.GetTorrentsByTrackerHostUnchecked(host)
+}
+
+// GetTorrentsByTrackerHostUnchecked returns the torrents whose current tracker is on host
+// without checking freshness.
+func (sm *SyncManager) GetTorrentsByTrackerHostUnchecked(host string) []Torrent {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	if sm.index == nil {
+		return nil
+	}
+
+	return sm.torrentsFromSet(sm.index.byTrackerHost[strings.ToLower(host)])
+}
+
+// GetTorrentsBySavePath returns the torrents saved to exactly path
+func (sm *SyncManager) GetTorrentsBySavePath(path string) []Torrent {
+	sm.ensureFreshData()
+	return sm.GetTorrentsBySavePathUnchecked(path)
+}
+
+// GetTorrentsBySavePathUnchecked returns the torrents saved to exactly path without checking freshness.
+func (sm *SyncManager) GetTorrentsBySavePathUnchecked(path string) []Torrent {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	if sm.index == nil {
+		return nil
+	}
+
+	return sm.torrentsFromSet(sm.index.bySavePath[path])
+}
+
+// torrentsFromSet resolves a set of hashes. Must be called with sm.mu held.
+func (sm *SyncManager) torrentsFromSet(set map[string]struct{}) []Torrent {
+	if len(set) == 0 {
+		return nil
+	}
+
+	result := make([]Torrent, 0, len(set))
+	for hash := range set {
+		if torrent, ok := sm.data.Torrents[hash]; ok {
+			result = append(result, torrent)
+		}
+	}
+
+	return result
+}
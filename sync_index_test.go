@@ -0,0 +1,74 @@
+package qbittorrent
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func torrentHashes(torrents []Torrent) []string {
+	hashes := make([]string, 0, len(torrents))
+	for _, torrent := range torrents {
+		hashes = append(hashes, torrent.Hash)
+	}
+	slices.Sort(hashes)
+	return hashes
+}
+
+// linearTorrents is the reference implementation the indexes must agree with
+func linearTorrents(sm *SyncManager, options TorrentFilterOptions) []string {
+	var result []Torrent
+	for _, torrent := range sm.GetDataUnchecked().Torrents {
+		if matchesTorrentFilter(torrent, options) {
+			result = append(result, torrent)
+		}
+	}
+	return torrentHashes(result)
+}
+
+func TestSyncManager_IndexedLookups(t *testing.T) {
+	sm := newSyncManagerWithBodies(
+		`{"rid":1,"full_update":true,"torrents":{
+			"aaa":{"category":"tv","tags":"hd, new","state":"uploading","tracker":"https://Tracker.One:443/announce","save_path":"/data/tv"},
+			"bbb":{"category":"tv","tags":"sd","state":"stalledDL","tracker":"udp://tracker.two:80","save_path":"/data/tv"},
+			"ccc":{"category":"movies","tags":"hd","state":"pausedUP","tracker":"","save_path":"/data/movies"}
+		},"categories":{},"tags":[],"server_state":{}}`,
+		`{"rid":2,"torrents":{
+			"bbb":{"category":"movies","state":"uploading","tags":"hd"},
+			"ddd":{"category":"tv","tags":"new","state":"downloading","tracker":"https://tracker.one/announce","save_path":"/data/tv"}
+		},"torrents_removed":["ccc"]}`,
+	)
+
+	queries := []TorrentFilterOptions{
+		{Category: "tv"},
+		{Category: "movies"},
+		{Category: "missing"},
+		{Tag: "hd"},
+		{Tag: " new "},
+		{Filter: TorrentFilterCompleted},
+		{Filter: TorrentFilterDownloading},
+		{Filter: TorrentFilterRunning},
+		{Category: "tv", Tag: "new", Filter: TorrentFilterActive},
+		{Hashes: []string{"aaa", "aaa", "zzz"}},
+		{},
+	}
+
+	for _, rid := range []int{1, 2} {
+		require.NoError(t, sm.Sync(context.Background()))
+
+		for _, query := range queries {
+			assert.Equal(t, linearTorrents(sm, query), torrentHashes(sm.GetTorrentsUnchecked(query)), "rid %d query %+v", rid, query)
+		}
+	}
+
+	assert.Equal(t, []string{"aaa", "ddd"}, torrentHashes(sm.GetTorrentsByTrackerHostUnchecked("tracker.one")))
+	assert.Equal(t, []string{"aaa", "bbb", "ddd"}, torrentHashes(sm.GetTorrentsBySavePathUnchecked("/data/tv")))
+	assert.Empty(t, sm.GetTorrentsBySavePathUnchecked("/data/movies"), "removed torrents must leave the index")
+
+	// bbb moved from tv to movies in the partial update
+	assert.Equal(t, []string{"bbb"}, torrentHashes(sm.GetTorrentsUnchecked(TorrentFilterOptions{Category: "movies"})))
+	assert.NotContains(t, sm.index.byTag, "sd")
+}
@@ -0,0 +1,166 @@
+package qbittorrent
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"slices"
+
+	"github.com/autobrr/go-qbittorrent/errors"
+)
+
+// torrentCursorVersion is bumped when the cursor encoding changes
+const torrentCursorVersion = 1
+
+// TorrentPage is one page of torrents returned by GetTorrentsPage
+type TorrentPage struct {
+	Torrents []Torrent `json:"torrents"`
+	// NextCursor resumes after the last torrent of this page; it is empty on the last page
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// torrentCursor is the decoded form of an opaque page cursor. It records the sort order it was
+// issued for and the sort values and hash of the last torrent on the page, so the next page starts
+// strictly after that position no matter which torrents were added or removed in between.
+type torrentCursor struct {
+	Version int                        `json:"v"`
+	Keys    []TorrentSortKey           `json:"k"`
+	Values  map[string]json.RawMessage `json:"p,omitempty"`
+	Hash    string                     `json:"h"`
+}
+
+// GetTorrentsPage returns the page of torrents matching options that follows cursor; pass an empty
+// cursor for the first page. options.Limit is the page size and options.Offset is ignored.
+// Torrents are ordered by options.SortKeys, or Sort and Reverse, with the hash as final tie-breaker,
+// so a cursor is only valid for the sort order it was issued with. Errors wrap ErrInvalidCursor.
+func (sm *SyncManager) GetTorrentsPage(options TorrentFilterOptions, cursor string) (TorrentPage, error) {
+	sm.ensureFreshData()
+	return sm.GetTorrentsPageUnchecked(options, cursor)
+}
+
+// GetTorrentsPageUnchecked is GetTorrentsPage without checking freshness.
+func (sm *SyncManager) GetTorrentsPageUnchecked(options TorrentFilterOptions, cursor string) (TorrentPage, error) {
+	keys := pageSortKeys(options)
+
+	var after *Torrent
+	if cursor != "" {
+		position, err := decodeTorrentCursor(cursor, keys)
+		if err != nil {
+			return TorrentPage{}, err
+		}
+		after = &position
+	}
+
+	sm.mu.RLock()
+	var torrents []Torrent
+	if sm.data != nil {
+		torrents = sm.collectMatchingTorrents(nil, options)
+	}
+	sm.mu.RUnlock()
+
+	sorter := newTorrentSorter(nil, keys)
+	if after != nil {
+		torrents = slices.DeleteFunc(torrents, func(t Torrent) bool {
+			return sorter.compareTorrents(&t, after) <= 0
+		})
+	}
+
+	applyTorrentSortKeys(torrents, keys)
+
+	if options.Limit <= 0 || len(torrents) <= options.Limit {
+		return TorrentPage{Torrents: torrents}, nil
+	}
+
+	page := TorrentPage{Torrents: slices.Clip(torrents[:options.Limit])}
+
+	next, err := encodeTorrentCursor(&page.Torrents[len(page.Torrents)-1], keys)
+	if err != nil {
+		return TorrentPage{}, err
+	}
+	page.NextCursor = next
+
+	return page, nil
+}
+
+// pageSortKeys returns the sort keys a page is ordered by, with unknown fields resolved to name
+// the way applyTorrentSortKeys does. Without any sort option pages are ordered by hash.
+func pageSortKeys(options TorrentFilterOptions) []TorrentSortKey {
+	keys := options.SortKeys
+	if len(keys) == 0 {
+		keys = []TorrentSortKey{{Field: "hash", Desc: options.Reverse}}
+		if options.Sort != "" {
+			keys[0].Field = options.Sort
+		}
+	}
+
+	resolved := make([]TorrentSortKey, len(keys))
+	for i, key := range keys {
+		if _, ok := torrentComparators[key.Field]; !ok || key.Field == "default" {
+			key.Field = "name"
+		}
+		resolved[i] = key
+	}
+
+	return resolved
+}
+
+func encodeTorrentCursor(last *Torrent, keys []TorrentSortKey) (string, error) {
+	raw, err := json.Marshal(last)
+	if err != nil {
+		return "", errors.Wrap(err, "could not encode cursor")
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return "", errors.Wrap(err, "could not encode cursor")
+	}
+
+	cursor := torrentCursor{
+		Version: torrentCursorVersion,
+		Keys:    keys,
+		Values:  make(map[string]json.RawMessage, len(keys)),
+		Hash:    last.Hash,
+	}
+	for _, key := range keys {
+		cursor.Values[key.Field] = fields[key.Field]
+	}
+
+	encoded, err := json.Marshal(cursor)
+	if err != nil {
+		return "", errors.Wrap(err, "could not encode cursor")
+	}
+
+	return base64.RawURLEncoding.EncodeToString(encoded), nil
+}
+
+// decodeTorrentCursor returns a torrent holding only the cursor's sort values and hash
+func decodeTorrentCursor(encoded string, keys []TorrentSortKey) (Torrent, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return Torrent{}, errors.Wrap(ErrInvalidCursor, "malformed cursor")
+	}
+
+	var cursor torrentCursor
+	if err := json.Unmarshal(raw, &cursor); err != nil {
+		return Torrent{}, errors.Wrap(ErrInvalidCursor, "malformed cursor")
+	}
+
+	if cursor.Version != torrentCursorVersion {
+		return Torrent{}, errors.Wrap(ErrInvalidCursor, "unsupported cursor version %d", cursor.Version)
+	}
+	if !slices.Equal(cursor.Keys, keys) {
+		return Torrent{}, errors.Wrap(ErrInvalidCursor, "cursor was issued for a different sort order")
+	}
+
+	values, err := json.Marshal(cursor.Values)
+	if err != nil {
+		return Torrent{}, errors.Wrap(ErrInvalidCursor, "malformed cursor")
+	}
+
+	var position Torrent
+	if err := json.Unmarshal(values, &position); err != nil {
+		return Torrent{}, errors.Wrap(ErrInvalidCursor, "malformed cursor values")
+	}
+	position.Hash = cursor.Hash
+
+	return position, nil
+}
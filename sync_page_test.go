@@ -0,0 +1,66 @@
+package qbittorrent
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func pageHashes(page TorrentPage) []string {
+	hashes := make([]string, 0, len(page.Torrents))
+	for _, torrent := range page.Torrents {
+		hashes = append(hashes, torrent.Hash)
+	}
+	return hashes
+}
+
+func TestSyncManager_GetTorrentsPage(t *testing.T) {
+	sm := newSyncManagerWithBodies(
+		`{"rid":1,"full_update":true,"torrents":{
+			"aaa":{"name":"a","ratio":1},
+			"bbb":{"name":"b","ratio":3},
+			"ccc":{"name":"c","ratio":2},
+			"ddd":{"name":"d","ratio":2},
+			"eee":{"name":"e","ratio":0.5}
+		},"categories":{},"tags":[],"server_state":{}}`,
+		`{"rid":2,"torrents":{"fff":{"name":"f","ratio":2.5},"ggg":{"name":"g","ratio":0.1}},"torrents_removed":["ccc"]}`,
+	)
+	ctx := context.Background()
+	require.NoError(t, sm.Sync(ctx))
+
+	options := TorrentFilterOptions{SortKeys: []TorrentSortKey{{Field: "ratio", Desc: true}}, Limit: 2}
+
+	first, err := sm.GetTorrentsPageUnchecked(options, "")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"bbb", "ddd"}, pageHashes(first))
+	require.NotEmpty(t, first.NextCursor)
+
+	// fff sorts before the cursor and ccc is removed; neither may shift the next page
+	require.NoError(t, sm.Sync(ctx))
+
+	second, err := sm.GetTorrentsPageUnchecked(options, first.NextCursor)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"aaa", "eee"}, pageHashes(second))
+
+	last, err := sm.GetTorrentsPageUnchecked(options, second.NextCursor)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ggg"}, pageHashes(last))
+	assert.Empty(t, last.NextCursor)
+}
+
+func TestSyncManager_GetTorrentsPage_InvalidCursor(t *testing.T) {
+	sm := newSyncManagerWithBodies(`{"rid":1,"full_update":true,"torrents":{"aaa":{},"bbb":{}},"categories":{},"tags":[],"server_state":{}}`)
+	require.NoError(t, sm.Sync(context.Background()))
+
+	page, err := sm.GetTorrentsPageUnchecked(TorrentFilterOptions{Limit: 1}, "")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"aaa"}, pageHashes(page))
+
+	_, err = sm.GetTorrentsPageUnchecked(TorrentFilterOptions{Limit: 1, Sort: "name"}, page.NextCursor)
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+
+	_, err = sm.GetTorrentsPageUnchecked(TorrentFilterOptions{Limit: 1}, "not a cursor")
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+}
@@ -0,0 +1,250 @@
+package qbittorrent
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/binary"
+	"encoding/gob"
+	"fmt"
+	"hash/crc32"
+	"hash/fnv"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/autobrr/go-qbittorrent/errors"
+)
+
+// syncSnapshotMagic identifies a SyncManager snapshot file
+var syncSnapshotMagic = [4]byte{'Q', 'B', 'S', 'M'}
+
+// syncSnapshotFormatVersion is bumped when the container layout changes
+const syncSnapshotFormatVersion uint16 = 1
+
+// syncSnapshotSchema fingerprints the MainData type graph, so adding, removing or
+// retyping a field in Torrent or any other nested type invalidates existing snapshots.
+var syncSnapshotSchema = schemaFingerprint(reflect.TypeOf(MainData{}))
+
+// syncSnapshotHeader precedes the gzip compressed gob payload
+type syncSnapshotHeader struct {
+	Magic         [4]byte
+	FormatVersion uint16
+	Schema        uint64
+	SavedAt       int64
+	PayloadSize   uint64
+	Checksum      uint32
+}
+
+// SaveSnapshot writes the current sync data to w.
+// It returns ErrInvalidSyncSnapshot if there is no data to save yet.
+func (sm *SyncManager) SaveSnapshot(w io.Writer) error {
+	sm.mu.RLock()
+	if sm.data == nil {
+		sm.mu.RUnlock()
+		return errors.Wrap(ErrInvalidSyncSnapshot, "no data to save")
+	}
+	data := sm.copyMainData(sm.data)
+	sm.mu.RUnlock()
+
+	var payload bytes.Buffer
+	zw := gzip.NewWriter(&payload)
+	if err := gob.NewEncoder(zw).Encode(data); err != nil {
+		return errors.Wrap(err, "could not encode sync snapshot")
+	}
+	if err := zw.Close(); err != nil {
+		return errors.Wrap(err, "could not compress sync snapshot")
+	}
+
+	header := syncSnapshotHeader{
+		Magic:         syncSnapshotMagic,
+		FormatVersion: syncSnapshotFormatVersion,
+		Schema:        syncSnapshotSchema,
+		SavedAt:       time.Now().UnixNano(),
+		PayloadSize:   uint64(payload.Len()),
+		Checksum:      crc32.ChecksumIEEE(payload.Bytes()),
+	}
+
+	if err := binary.Write(w, binary.BigEndian, header); err != nil {
+		return errors.Wrap(err, "could not write sync snapshot header")
+	}
+	if _, err := w.Write(payload.Bytes()); err != nil {
+		return errors.Wrap(err, "could not write sync snapshot")
+	}
+
+	return nil
+}
+
+// LoadSnapshot replaces the sync data with a snapshot written by SaveSnapshot and returns the
+// time it was saved. The next sync is a full sync, so the loaded data is only served until the
+// server has answered. The data counts as synced at the time it was saved.
+// Snapshots written with a different schema return ErrSyncSnapshotSchemaMismatch.
+func (sm *SyncManager) LoadSnapshot(r io.Reader) (time.Time, error) {
+	var header syncSnapshotHeader
+	if err := binary.Read(r, binary.BigEndian, &header); err != nil {
+		return time.Time{}, errors.Wrap(ErrInvalidSyncSnapshot, "could not read header: %v", err)
+	}
+
+	switch {
+	case header.Magic != syncSnapshotMagic:
+		return time.Time{}, errors.Wrap(ErrInvalidSyncSnapshot, "bad magic")
+	case header.FormatVersion != syncSnapshotFormatVersion:
+		return time.Time{}, errors.Wrap(ErrInvalidSyncSnapshot, "unsupported format version: %d", header.FormatVersion)
+	case header.Schema != syncSnapshotSchema:
+		return time.Time{}, ErrSyncSnapshotSchemaMismatch
+	}
+
+	// read through a limit instead of trusting PayloadSize for a single allocation
+	payload, err := io.ReadAll(io.LimitReader(r, int64(header.PayloadSize)))
+	if err != nil {
+		return time.Time{}, errors.Wrap(ErrInvalidSyncSnapshot, "could not read payload: %v", err)
+	}
+	if uint64(len(payload)) != header.PayloadSize {
+		return time.Time{}, errors.Wrap(ErrInvalidSyncSnapshot, "truncated payload")
+	}
+
+	if crc32.ChecksumIEEE(payload) != header.Checksum {
+		return time.Time{}, ErrSyncSnapshotChecksum
+	}
+
+	zr, err := gzip.NewReader(bytes.NewReader(payload))
+	if err != nil {
+		return time.Time{}, errors.Wrap(ErrInvalidSyncSnapshot, "could not decompress payload: %v", err)
+	}
+	defer zr.Close()
+
+	var data MainData
+	if err := gob.NewDecoder(zr).Decode(&data); err != nil {
+		return time.Time{}, errors.Wrap(ErrInvalidSyncSnapshot, "could not decode payload: %v", err)
+	}
+
+	// the server's rid does not survive a restart, so always start over with a full sync
+	data.Rid = 0
+	data.ensureInitialized()
+
+	savedAt := time.Unix(0, header.SavedAt)
+
+	sm.mu.Lock()
+	sm.data = &data
+	sm.rid = 0
+	sm.lastSync = savedAt
+	sm.lastSuccessfulSync = savedAt
+	sm.updateAllTorrents()
+	sm.rebuildIndex()
+	sm.mu.Unlock()
+
+	return savedAt, nil
+}
+
+// SaveSnapshotFile atomically writes a snapshot to path.
+func (sm *SyncManager) SaveSnapshotFile(path string) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return errors.Wrap(err, "could not create sync snapshot file")
+	}
+	defer os.Remove(tmp.Name())
+
+	w := bufio.NewWriter(tmp)
+	if err := sm.SaveSnapshot(w); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := w.Flush(); err != nil {
+		tmp.Close()
+		return errors.Wrap(err, "could not write sync snapshot file")
+	}
+
+	if err := tmp.Close(); err != nil {
+		return errors.Wrap(err, "could not write sync snapshot file")
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return errors.Wrap(err, "could not replace sync snapshot file")
+	}
+
+	return nil
+}
+
+// LoadSnapshotFile loads a snapshot written by SaveSnapshotFile.
+func (sm *SyncManager) LoadSnapshotFile(path string) (time.Time, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return time.Time{}, errors.Wrap(err, "could not open sync snapshot file")
+	}
+	defer f.Close()
+
+	return sm.LoadSnapshot(bufio.NewReader(f))
+}
+
+// snapshotLoop writes a snapshot every SnapshotInterval and once more when ctx is done
+func (sm *SyncManager) snapshotLoop(ctx context.Context) {
+	var tick <-chan time.Time
+	if sm.options.SnapshotInterval > 0 {
+		ticker := time.NewTicker(sm.options.SnapshotInterval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			sm.saveSnapshotFile()
+			return
+		case <-tick:
+			sm.saveSnapshotFile()
+		}
+	}
+}
+
+func (sm *SyncManager) saveSnapshotFile() {
+	if err := sm.SaveSnapshotFile(sm.options.SnapshotPath); err != nil && !errors.Is(err, ErrInvalidSyncSnapshot) {
+		if sm.options.OnError != nil {
+			sm.options.OnError(err)
+		}
+	}
+}
+
+// schemaFingerprint hashes the field names, tags and types reachable from t
+func schemaFingerprint(t reflect.Type) uint64 {
+	var b strings.Builder
+	describeType(&b, t, map[reflect.Type]bool{})
+
+	h := fnv.New64a()
+	h.Write([]byte(b.String()))
+	return h.Sum64()
+}
+
+func describeType(b *strings.Builder, t reflect.Type, seen map[reflect.Type]bool) {
+	switch t.Kind() {
+	case reflect.Struct:
+		if seen[t] {
+			b.WriteString(t.String())
+			return
+		}
+		seen[t] = true
+
+		fmt.Fprintf(b, "%s{", t.String())
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			fmt.Fprintf(b, "%s %q ", f.Name, f.Tag)
+			describeType(b, f.Type, seen)
+			b.WriteString(";")
+		}
+		b.WriteString("}")
+	case reflect.Map:
+		b.WriteString("map[")
+		describeType(b, t.Key(), seen)
+		b.WriteString("]")
+		describeType(b, t.Elem(), seen)
+	case reflect.Slice, reflect.Array, reflect.Pointer:
+		b.WriteString(t.Kind().String())
+		describeType(b, t.Elem(), seen)
+	default:
+		b.WriteString(t.String())
+	}
+}
@@ -0,0 +1,209 @@
+package qbittorrent
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/autobrr/go-qbittorrent/errors"
+)
+
+const snapshotTestBody = `{"rid":7,"full_update":true,"torrents":{"aaa":{"name":"a","state":"uploading","progress":1,"trackers":[{"url":"http://tracker"}]}},"categories":{"tv":{"savePath":"/tv"}},"tags":["x"],"server_state":{"dl_info_speed":10}}`
+
+// snapshotTempDir is like t.TempDir but tolerates the final snapshot being
+// written by the background loop while the directory is cleaned up.
+func snapshotTempDir(t *testing.T) string {
+	t.Helper()
+
+	dir, err := os.MkdirTemp("", "qbt-snapshot")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+	return dir
+}
+
+func syncedSnapshotManager(t *testing.T) *SyncManager {
+	t.Helper()
+
+	sm := newSyncManagerWithBodies(snapshotTestBody)
+	require.NoError(t, sm.Sync(context.Background()))
+	return sm
+}
+
+func TestSyncManager_SnapshotRoundTrip(t *testing.T) {
+	sm := syncedSnapshotManager(t)
+
+	var buf bytes.Buffer
+	require.NoError(t, sm.SaveSnapshot(&buf))
+
+	restored := NewSyncManager(NewClient(Config{Host: "http://qbit.test"}))
+	savedAt, err := restored.LoadSnapshot(&buf)
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now(), savedAt, time.Minute)
+
+	want := sm.GetDataUnchecked()
+	got := restored.GetDataUnchecked()
+	assert.Equal(t, want.Torrents, got.Torrents)
+	assert.Equal(t, want.Categories, got.Categories)
+	assert.Equal(t, want.Tags, got.Tags)
+	assert.Equal(t, want.ServerState, got.ServerState)
+
+	// a warm start must still do a full sync
+	assert.Zero(t, got.Rid)
+
+	torrent, ok := restored.GetTorrentUnchecked("aaa")
+	require.True(t, ok)
+	assert.Equal(t, "a", torrent.Name)
+}
+
+func TestSyncManager_LoadSnapshotRejectsCorruption(t *testing.T) {
+	sm := syncedSnapshotManager(t)
+
+	var buf bytes.Buffer
+	require.NoError(t, sm.SaveSnapshot(&buf))
+	valid := buf.Bytes()
+	headerSize := binary.Size(syncSnapshotHeader{})
+
+	t.Run("checksum", func(t *testing.T) {
+		data := bytes.Clone(valid)
+		data[len(data)-1] ^= 0xff
+
+		_, err := NewSyncManager(nil).LoadSnapshot(bytes.NewReader(data))
+		assert.True(t, errors.Is(err, ErrSyncSnapshotChecksum), "got %v", err)
+	})
+
+	t.Run("schema", func(t *testing.T) {
+		data := bytes.Clone(valid)
+		binary.BigEndian.PutUint64(data[6:14], syncSnapshotSchema+1)
+
+		_, err := NewSyncManager(nil).LoadSnapshot(bytes.NewReader(data))
+		assert.True(t, errors.Is(err, ErrSyncSnapshotSchemaMismatch), "got %v", err)
+	})
+
+	t.Run("truncated", func(t *testing.T) {
+		_, err := NewSyncManager(nil).LoadSnapshot(bytes.NewReader(valid[:headerSize+3]))
+		assert.True(t, errors.Is(err, ErrInvalidSyncSnapshot), "got %v", err)
+	})
+
+	t.Run("garbage", func(t *testing.T) {
+		_, err := NewSyncManager(nil).LoadSnapshot(bytes.NewReader([]byte("not a snapshot at all, really")))
+		assert.True(t, errors.Is(err, ErrInvalidSyncSnapshot), "got %v", err)
+	})
+}
+
+func TestSchemaFingerprintChangesWithFields(t *testing.T) {
+	type v1 struct {
+		Name string `json:"name"`
+	}
+	type v2 struct {
+		Name string `json:"name"`
+		Size int64  `json:"size"`
+	}
+
+	assert.NotEqual(t, schemaFingerprint(reflect.TypeOf(v1{})), schemaFingerprint(reflect.TypeOf(v2{})))
+	assert.Equal(t, syncSnapshotSchema, schemaFingerprint(reflect.TypeOf(MainData{})))
+}
+
+func TestSyncManager_StartWarmFromSnapshotFile(t *testing.T) {
+	path := filepath.Join(snapshotTempDir(t), "maindata.snapshot")
+	require.NoError(t, syncedSnapshotManager(t).SaveSnapshotFile(path))
+
+	// the server never answers, yet the snapshot is served right away
+	block := make(chan struct{})
+	defer close(block)
+
+	client := NewClient(Config{Host: "http://qbit.test", APIKey: "test-key", RetryAttempts: 1})
+	client.http.Transport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		select {
+		case <-block:
+		case <-req.Context().Done():
+		}
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewReader(nil)), Header: make(http.Header)}, nil
+	})
+
+	opts := DefaultSyncOptions()
+	opts.SnapshotPath = path
+	sm := NewSyncManager(client, opts)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	require.NoError(t, sm.Start(ctx))
+
+	torrent, ok := sm.GetTorrentUnchecked("aaa")
+	require.True(t, ok)
+	assert.Equal(t, "a", torrent.Name)
+}
+
+func TestSyncManager_StartWarmServesSnapshotDuringSync(t *testing.T) {
+	path := filepath.Join(snapshotTempDir(t), "maindata.snapshot")
+	require.NoError(t, syncedSnapshotManager(t).SaveSnapshotFile(path))
+
+	requested := make(chan struct{}, 1)
+	block := make(chan struct{})
+	defer close(block)
+
+	client := NewClient(Config{Host: "http://qbit.test", APIKey: "test-key", RetryAttempts: 1})
+	client.http.Transport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		select {
+		case requested <- struct{}{}:
+		default:
+		}
+		select {
+		case <-block:
+		case <-req.Context().Done():
+		}
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewReader(nil)), Header: make(http.Header)}, nil
+	})
+
+	// DynamicSync would otherwise sync on read, as the snapshot is older than SyncInterval
+	opts := DefaultSyncOptions()
+	opts.SnapshotPath = path
+	opts.SyncInterval = time.Nanosecond
+	sm := NewSyncManager(client, opts)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	require.NoError(t, sm.Start(ctx))
+	<-requested
+
+	done := make(chan []Torrent, 1)
+	go func() { done <- sm.GetTorrents(TorrentFilterOptions{}) }()
+
+	select {
+	case torrents := <-done:
+		require.Len(t, torrents, 1)
+		assert.Equal(t, "a", torrents[0].Name)
+	case <-time.After(5 * time.Second):
+		t.Fatal("GetTorrents waited for the initial sync instead of serving the snapshot")
+	}
+
+	assert.False(t, sm.LastSyncTime().IsZero(), "the snapshot time counts as the last sync")
+}
+
+func TestSyncManager_StartWithoutSnapshotFile(t *testing.T) {
+	var errs []error
+
+	sm := newSyncManagerWithBodies(snapshotTestBody)
+	sm.options.SnapshotPath = filepath.Join(snapshotTempDir(t), "missing.snapshot")
+	sm.options.OnError = func(err error) { errs = append(errs, err) }
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	require.NoError(t, sm.Start(ctx))
+	assert.Empty(t, errs, "a missing snapshot is not an error")
+
+	_, ok := sm.GetTorrentUnchecked("aaa")
+	assert.True(t, ok)
+}
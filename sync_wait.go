@@ -0,0 +1,169 @@
+package qbittorrent
+
+import (
+	"context"
+	"slices"
+	"sync/atomic"
+	"time"
+
+	"github.com/autobrr/go-qbittorrent/errors"
+)
+
+// WaitProgress is reported by WaitForCompletion whenever a watched torrent changes.
+type WaitProgress struct {
+	Torrent   Torrent
+	Completed int
+	Total     int
+}
+
+// WaitForCompletionOptions configures WaitForCompletion
+type WaitForCompletionOptions struct {
+	// OnProgress is called whenever a watched torrent changes
+	OnProgress func(WaitProgress)
+}
+
+// WaitForTorrent blocks until the torrent with the given hash satisfies predicate and returns it.
+// The torrent is checked once immediately and then after every sync that touches it, so it may
+// be called before a freshly added torrent shows up. If the manager is not auto-syncing,
+// WaitForTorrent syncs every SyncInterval while it waits.
+// It returns ErrTorrentNotFound if the torrent is removed, or ctx.Err() once ctx is done.
+func (sm *SyncManager) WaitForTorrent(ctx context.Context, hash string, predicate func(Torrent) bool) (Torrent, error) {
+	var result Torrent
+
+	err := sm.waitFor(ctx, func(h string) bool { return h == hash }, func() (bool, error) {
+		torrent, ok := sm.GetTorrentUnchecked(hash)
+		if !ok {
+			return false, nil
+		}
+
+		result = torrent
+		return predicate(torrent), nil
+	})
+
+	return result, err
+}
+
+// WaitForState blocks until the torrent with the given hash is in one of states.
+func (sm *SyncManager) WaitForState(ctx context.Context, hash string, states ...TorrentState) (Torrent, error) {
+	return sm.WaitForTorrent(ctx, hash, func(t Torrent) bool {
+		return slices.Contains(states, t.State)
+	})
+}
+
+// WaitForCompletion blocks until every torrent in hashes has finished downloading.
+func (sm *SyncManager) WaitForCompletion(ctx context.Context, hashes []string, options ...WaitForCompletionOptions) error {
+	var opts WaitForCompletionOptions
+	if len(options) > 0 {
+		opts = options[0]
+	}
+
+	progress := make(map[string]float64, len(hashes))
+
+	return sm.waitFor(ctx, func(h string) bool { return slices.Contains(hashes, h) }, func() (bool, error) {
+		var changed []Torrent
+		completed := 0
+
+		for _, hash := range hashes {
+			torrent, ok := sm.GetTorrentUnchecked(hash)
+			if !ok {
+				continue
+			}
+
+			if torrent.Progress >= 1 {
+				completed++
+			}
+
+			if last, seen := progress[hash]; !seen || last != torrent.Progress {
+				progress[hash] = torrent.Progress
+				changed = append(changed, torrent)
+			}
+		}
+
+		if opts.OnProgress != nil {
+			for _, torrent := range changed {
+				opts.OnProgress(WaitProgress{Torrent: torrent, Completed: completed, Total: len(hashes)})
+			}
+		}
+
+		return completed == len(hashes), nil
+	})
+}
+
+// waitFor calls check once and then again after every event for a hash accepted by watch,
+// until check reports done or returns an error.
+func (sm *SyncManager) waitFor(ctx context.Context, watch func(hash string) bool, check func() (bool, error)) error {
+	// a full buffer means we may have missed an event we care about
+	var dirty atomic.Bool
+
+	subCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	events := sm.Subscribe(subCtx, SubscribeOptions{
+		DropPolicy: DropOldest,
+		OnDrop:     func(SyncEvent) { dirty.Store(true) },
+	})
+
+	if sm.GetDataUnchecked() == nil {
+		if err := sm.Sync(ctx); err != nil {
+			return err
+		}
+	}
+
+	if done, err := check(); done || err != nil {
+		return err
+	}
+
+	var tick <-chan time.Time
+	if !sm.options.AutoSync {
+		ticker := time.NewTicker(sm.options.SyncInterval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+
+		case <-tick:
+			// errors are reported through OnError; keep waiting for the next sync
+			_ = sm.Sync(ctx)
+
+		case event, ok := <-events:
+			if !ok {
+				return ctx.Err()
+			}
+
+			hash := syncEventHash(event)
+			if !watch(hash) && !dirty.Swap(false) {
+				continue
+			}
+
+			if _, removed := event.(TorrentRemoved); removed && watch(hash) {
+				return errors.Wrap(ErrTorrentNotFound, "torrent removed while waiting; hash: %s", hash)
+			}
+
+			if done, err := check(); done || err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// syncEventHash returns the torrent hash an event refers to, or "" for non-torrent events
+func syncEventHash(event SyncEvent) string {
+	switch e := event.(type) {
+	case TorrentAdded:
+		return e.Torrent.Hash
+	case TorrentRemoved:
+		return e.Hash
+	case TorrentStateChanged:
+		return e.Hash
+	case TorrentCompleted:
+		return e.Torrent.Hash
+	case TorrentFieldChanged:
+		return e.Hash
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,80 @@
+package qbittorrent
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/autobrr/go-qbittorrent/errors"
+)
+
+func TestSyncManager_WaitForState(t *testing.T) {
+	sm := newSyncManagerWithBodies(
+		`{"rid":1,"full_update":true,"torrents":{},"categories":{},"tags":[],"server_state":{}}`,
+		`{"rid":2,"torrents":{"aaa":{"name":"magnet","state":"metaDL"}}}`,
+		`{"rid":3,"torrents":{"aaa":{"state":"checkingDL"}}}`,
+		`{"rid":4,"torrents":{"aaa":{"state":"stoppedDL"}}}`,
+	)
+	sm.options.SyncInterval = 5 * time.Millisecond
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// the torrent does not exist yet when we start waiting
+	torrent, err := sm.WaitForState(ctx, "aaa", TorrentStateStoppedDl, TorrentStatePausedDl)
+	require.NoError(t, err)
+	assert.Equal(t, "magnet", torrent.Name)
+	assert.Equal(t, TorrentStateStoppedDl, torrent.State)
+}
+
+func TestSyncManager_WaitForTorrent_Removed(t *testing.T) {
+	sm := newSyncManagerWithBodies(
+		`{"rid":1,"full_update":true,"torrents":{"aaa":{"state":"checkingUP"}},"categories":{},"tags":[],"server_state":{}}`,
+		`{"rid":2,"torrents_removed":["aaa"]}`,
+	)
+	sm.options.SyncInterval = 5 * time.Millisecond
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := sm.WaitForTorrent(ctx, "aaa", func(t Torrent) bool { return t.State == TorrentStateUploading })
+	assert.True(t, errors.Is(err, ErrTorrentNotFound), "got %v", err)
+}
+
+func TestSyncManager_WaitForTorrent_Timeout(t *testing.T) {
+	sm := newSyncManagerWithBodies(`{"rid":1,"full_update":true,"torrents":{"aaa":{"state":"downloading"}},"categories":{},"tags":[],"server_state":{}}`)
+	sm.options.SyncInterval = 5 * time.Millisecond
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := sm.WaitForState(ctx, "aaa", TorrentStateUploading)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestSyncManager_WaitForCompletion(t *testing.T) {
+	sm := newSyncManagerWithBodies(
+		`{"rid":1,"full_update":true,"torrents":{"aaa":{"progress":0.2},"bbb":{"progress":1}},"categories":{},"tags":[],"server_state":{}}`,
+		`{"rid":2,"torrents":{"aaa":{"progress":0.6}}}`,
+		`{"rid":3,"torrents":{"aaa":{"progress":1}}}`,
+	)
+	sm.options.SyncInterval = 5 * time.Millisecond
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var progress []WaitProgress
+	err := sm.WaitForCompletion(ctx, []string{"aaa", "bbb"}, WaitForCompletionOptions{
+		OnProgress: func(p WaitProgress) { progress = append(progress, p) },
+	})
+	require.NoError(t, err)
+
+	require.NotEmpty(t, progress)
+	last := progress[len(progress)-1]
+	assert.Equal(t, "aaa", last.Torrent.Hash)
+	assert.Equal(t, 2, last.Completed)
+	assert.Equal(t, 2, last.Total)
+}
@@ -0,0 +1,60 @@
+package qbittorrent
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_SetSavePathAndDownloadPath(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		want   error
+	}{
+		{name: "ok", status: http.StatusOK, want: nil},
+		{name: "invalid path", status: http.StatusBadRequest, want: ErrInvalidTorrentPath},
+		{name: "forbidden", status: http.StatusForbidden, want: ErrTorrentPathForbidden},
+		{name: "conflict", status: http.StatusConflict, want: ErrTorrentPathConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var paths []string
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("/api/v2/app/webapiVersion", func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = w.Write([]byte("2.8.4"))
+			})
+			mux.HandleFunc("/api/v2/torrents/", func(w http.ResponseWriter, r *http.Request) {
+				require.NoError(t, r.ParseForm())
+				assert.Equal(t, "a|b", r.FormValue("id"))
+				assert.Equal(t, "/data/incomplete", r.FormValue("path"))
+				paths = append(paths, r.URL.Path)
+				w.WriteHeader(tt.status)
+			})
+
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			// api key auth so a 403 is returned to the caller instead of triggering a re-login
+			client := NewClient(Config{Host: server.URL, APIKey: "key"})
+
+			errSave := client.SetSavePath([]string{"a", "b"}, "/data/incomplete")
+			errDownload := client.SetDownloadPath([]string{"a", "b"}, "/data/incomplete")
+
+			assert.Equal(t, []string{"/api/v2/torrents/setSavePath", "/api/v2/torrents/setDownloadPath"}, paths)
+
+			if tt.want == nil {
+				assert.NoError(t, errSave)
+				assert.NoError(t, errDownload)
+				return
+			}
+			assert.ErrorIs(t, errSave, tt.want)
+			assert.ErrorIs(t, errDownload, tt.want)
+		})
+	}
+}
@@ -0,0 +1,289 @@
+package qbittorrent
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"maps"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/autobrr/go-qbittorrent/errors"
+)
+
+// uploadFile is a torrent file sent as a "torrents" part of a multipart upload
+type uploadFile struct {
+	size int64
+	open func() (io.ReadCloser, error)
+	// ids are the expected torrent ids of the file, see TorrentAddResponse.ExpectedTorrentIds
+	ids []string
+}
+
+func memoryUploadFile(buf []byte) uploadFile {
+	return uploadFile{
+		size: int64(len(buf)),
+		open: func() (io.ReadCloser, error) { return io.NopCloser(bytes.NewReader(buf)), nil },
+		ids:  expectedTorrentIds(buf),
+	}
+}
+
+func diskUploadFile(path string) (uploadFile, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return uploadFile{}, errors.Wrap(err, "error opening file %v", path)
+	}
+
+	return uploadFile{
+		size: info.Size(),
+		open: func() (io.ReadCloser, error) { return os.Open(path) },
+		ids:  expectedTorrentIdsFromFile(path),
+	}, nil
+}
+
+// uploadForm is a multipart torrents/add body that streams its files from their sources.
+// The body is never held in memory and can be replayed for every retry.
+type uploadForm struct {
+	boundary string
+	files    []uploadFile
+	fields   map[string]string
+}
+
+func newUploadForm(files []uploadFile, fields map[string]string) *uploadForm {
+	return &uploadForm{
+		boundary: multipart.NewWriter(io.Discard).Boundary(),
+		files:    files,
+		fields:   fields,
+	}
+}
+
+func (f *uploadForm) contentType() string {
+	return "multipart/form-data; boundary=" + f.boundary
+}
+
+// size returns the exact length of the body, so it can be sent with a Content-Length
+func (f *uploadForm) size() int64 {
+	var framing countingWriter
+	_ = f.write(&framing, false) // countingWriter never fails
+
+	size := int64(framing)
+	for _, file := range f.files {
+		size += file.size
+	}
+
+	return size
+}
+
+// body streams the form through a pipe. Each call starts over from the sources, which makes it
+// usable as the request's GetBody.
+func (f *uploadForm) body() (io.ReadCloser, error) {
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(f.write(pw, true))
+	}()
+
+	return pr, nil
+}
+
+// write writes the form to w, leaving out the file contents unless contents is set
+func (f *uploadForm) write(w io.Writer, contents bool) error {
+	writer := multipart.NewWriter(w)
+	if err := writer.SetBoundary(f.boundary); err != nil {
+		return errors.Wrap(err, "error setting boundary")
+	}
+
+	for _, file := range f.files {
+		fw, err := writer.CreateFormFile("torrents", generateTorrentName())
+		if err != nil {
+			return errors.Wrap(err, "error initializing file field")
+		}
+		if !contents {
+			continue
+		}
+		if err := file.copyTo(fw); err != nil {
+			return err
+		}
+	}
+
+	for key, val := range f.fields {
+		if err := writer.WriteField(key, val); err != nil {
+			return errors.Wrap(err, "error writing field %v", key)
+		}
+	}
+
+	return writer.Close()
+}
+
+func (f uploadFile) copyTo(w io.Writer) error {
+	r, err := f.open()
+	if err != nil {
+		return errors.Wrap(err, "error opening file")
+	}
+	defer r.Close()
+
+	n, err := io.Copy(w, r)
+	if err != nil {
+		return errors.Wrap(err, "error writing file contents")
+	}
+	if n != f.size {
+		return errors.New("file changed from %d to %d bytes during upload", f.size, n)
+	}
+
+	return nil
+}
+
+type countingWriter int64
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	*w += countingWriter(len(p))
+	return len(p), nil
+}
+
+// splitUploadForms spreads files over as few forms as possible, keeping each form within maxSize
+// bytes unless maxSize is not positive. fields are sent with every form and firstFields only with
+// the first one. A file too large for a form of its own is still sent, and left to the server.
+func splitUploadForms(files []uploadFile, fields, firstFields map[string]string, maxSize int64) []*uploadForm {
+	first := make(map[string]string, len(fields)+len(firstFields))
+	maps.Copy(first, fields)
+	maps.Copy(first, firstFields)
+
+	form := newUploadForm(nil, first)
+	if maxSize <= 0 {
+		form.files = files
+		return []*uploadForm{form}
+	}
+
+	var forms []*uploadForm
+	size, partSize := form.size(), form.partSize()
+	for _, file := range files {
+		if len(form.files) > 0 && size+partSize+file.size > maxSize {
+			forms = append(forms, form)
+			form = newUploadForm(nil, fields)
+			size, partSize = form.size(), form.partSize()
+		}
+
+		form.files = append(form.files, file)
+		size += partSize + file.size
+	}
+
+	return append(forms, form)
+}
+
+// partSize returns an upper bound on the framing one more file adds to an empty form.
+// The first part is two bytes shorter, as it is not preceded by a line break.
+func (f *uploadForm) partSize() int64 {
+	one := &uploadForm{boundary: f.boundary, files: make([]uploadFile, 1), fields: f.fields}
+	two := &uploadForm{boundary: f.boundary, files: make([]uploadFile, 2), fields: f.fields}
+
+	return two.size() - one.size()
+}
+
+// postUploadFormCtx posts form, streaming the body instead of buffering it.
+func (c *Client) postUploadFormCtx(ctx context.Context, endpoint string, form *uploadForm) (*http.Response, error) {
+	reqUrl := c.buildUrl(endpoint, nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, reqUrl, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating request")
+	}
+
+	if c.cfg.BasicUser != "" && c.cfg.BasicPass != "" {
+		req.SetBasicAuth(c.cfg.BasicUser, c.cfg.BasicPass)
+	}
+	if c.usingAPIKeyAuth() {
+		c.setAPIKeyAuthHeader(req)
+	} else {
+		cookieURL, _ := url.Parse(c.buildUrl("/", nil)) //nolint:errcheck // buildUrl returns valid URL
+		if len(c.http.Jar.Cookies(cookieURL)) == 0 {
+			if loginErr := c.LoginCtx(ctx); loginErr != nil {
+				return nil, errors.Wrap(loginErr, "qbit re-login failed")
+			}
+		}
+	}
+
+	// the body is only opened by retryDo, so a middleware that never sends the request
+	// leaves no writer running and no file open
+	req.Header.Set("Content-Type", form.contentType())
+	req.ContentLength = form.size()
+	req.GetBody = form.body
+
+	resp, err := c.send(ctx, &Request{Endpoint: endpoint, Method: http.MethodPost, Params: form.fields, HTTP: req}, c.retryHandler)
+	if err != nil {
+		return nil, errors.Wrap(err, "error making post multi-file request")
+	}
+
+	return resp, nil
+}
+
+// uploadTorrentsCtx adds files, split over as many requests as the client's max request size
+// needs, and merges the responses. firstFields, such as the urls to add, are only sent once.
+// If a request fails, the merged responses of the requests before it are returned with the error.
+func (c *Client) uploadTorrentsCtx(ctx context.Context, files []uploadFile, fields, firstFields map[string]string) (*TorrentAddResponse, error) {
+	forms := splitUploadForms(files, fields, firstFields, c.maxRequestSize)
+
+	var res *TorrentAddResponse
+	for i, form := range forms {
+		part, err := c.uploadTorrentFormCtx(ctx, form)
+		if err != nil {
+			if len(forms) > 1 {
+				err = errors.Wrap(err, "request %d of %d", i+1, len(forms))
+			}
+			return res, err
+		}
+
+		if res == nil {
+			res = part
+		} else {
+			res.merge(part)
+		}
+	}
+
+	return res, nil
+}
+
+func (c *Client) uploadTorrentFormCtx(ctx context.Context, form *uploadForm) (*TorrentAddResponse, error) {
+	resp, err := c.postUploadFormCtx(ctx, "torrents/add", form)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not add torrents")
+	}
+
+	defer drainAndClose(resp)
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusAccepted, http.StatusNoContent:
+	case http.StatusConflict:
+		return nil, errors.Wrap(ErrTorrentAddFailed, "could not add torrents | conflicts detected")
+	case http.StatusUnsupportedMediaType:
+		return nil, errors.Wrap(ErrTorrentAddFailed, "could not add torrents | torrent file not valid")
+	default:
+		return nil, errors.Wrap(ErrUnexpectedStatus, "could not add torrents | unexpected status code: %d", resp.StatusCode)
+	}
+
+	res := TorrentAddResponse{SuccessCount: 1}
+
+	switch contentType := resp.Header.Get("Content-Type"); {
+	case strings.HasPrefix(contentType, "application/json"):
+		if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+			return nil, errors.Wrap(err, "could not unmarshal body")
+		}
+	case !strings.HasPrefix(contentType, "text/plain"):
+		return nil, errors.Wrap(ErrUnexpectedContentType, "could not add torrents | unexpected content-type: %s", contentType)
+	}
+
+	for _, file := range form.files {
+		res.ExpectedTorrentIds = append(res.ExpectedTorrentIds, file.ids...)
+	}
+
+	return &res, nil
+}
+
+// merge adds the counts and torrent ids of other, the response to a later request of the same batch
+func (r *TorrentAddResponse) merge(other *TorrentAddResponse) {
+	r.SuccessCount += other.SuccessCount
+	r.PendingCount += other.PendingCount
+	r.FailureCount += other.FailureCount
+	r.AddedTorrentIds = append(r.AddedTorrentIds, other.AddedTorrentIds...)
+	r.ExpectedTorrentIds = append(r.ExpectedTorrentIds, other.ExpectedTorrentIds...)
+}
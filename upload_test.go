@@ -0,0 +1,151 @@
+package qbittorrent
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitUploadForms(t *testing.T) {
+	var files []uploadFile
+	for i := range 20 {
+		files = append(files, memoryUploadFile(bytes.Repeat([]byte{'x'}, 100+i*10)))
+	}
+	fields := map[string]string{"savepath": "/data"}
+
+	const maxSize = 1500
+	forms := splitUploadForms(files, fields, map[string]string{"urls": "https://example.com/a.torrent"}, maxSize)
+	require.Greater(t, len(forms), 1)
+
+	count := 0
+	for i, form := range forms {
+		body, err := form.body()
+		require.NoError(t, err)
+		data, err := io.ReadAll(body)
+		require.NoError(t, err)
+
+		assert.Equal(t, form.size(), int64(len(data)))
+		assert.LessOrEqual(t, len(data), maxSize)
+		assert.Equal(t, "/data", form.fields["savepath"])
+		assert.Equal(t, i == 0, form.fields["urls"] != "", "urls are only sent with the first form")
+		count += len(form.files)
+	}
+	assert.Equal(t, len(files), count)
+
+	assert.Len(t, splitUploadForms(files, fields, nil, 0), 1)
+	assert.Len(t, splitUploadForms(files, fields, nil, 1), len(files), "oversized files are sent on their own")
+}
+
+func TestClient_AddTorrentsFromMemory_Chunked(t *testing.T) {
+	var requests []*http.Request
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v2/torrents/add", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseMultipartForm(1<<20))
+		requests = append(requests, r)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"success_count":` + strconv.Itoa(len(r.MultipartForm.File["torrents"])) + `}`))
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client := NewClient(Config{Host: server.URL, APIKey: "key", MaxRequestSize: 2048})
+
+	torrent := []byte(infohashTestTorrent)
+	files := [][]byte{torrent, bytes.Repeat([]byte{'x'}, 1000), bytes.Repeat([]byte{'y'}, 1000), torrent}
+
+	res, err := client.AddTorrentsFromMemory(files, map[string]string{"category": "tv"})
+	require.NoError(t, err)
+
+	require.Greater(t, len(requests), 1)
+	count := 0
+	for _, r := range requests {
+		assert.LessOrEqual(t, r.ContentLength, int64(2048))
+		assert.Equal(t, []string{"tv"}, r.MultipartForm.Value["category"])
+		count += len(r.MultipartForm.File["torrents"])
+	}
+	assert.Equal(t, len(files), count)
+	assert.Equal(t, int64(4), res.SuccessCount)
+	assert.Equal(t, []string{infohashTestHash, "", "", infohashTestHash}, res.ExpectedTorrentIds)
+}
+
+func TestClient_AddTorrentsFromMemory_ChunkFailure(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) > 1 {
+			w.WriteHeader(http.StatusUnsupportedMediaType)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"success_count":1}`))
+	}))
+	t.Cleanup(server.Close)
+
+	client := NewClient(Config{Host: server.URL, APIKey: "key", MaxRequestSize: 1024})
+
+	files := [][]byte{bytes.Repeat([]byte{'x'}, 800), bytes.Repeat([]byte{'y'}, 800)}
+	res, err := client.AddTorrentsFromMemory(files, nil)
+	require.ErrorIs(t, err, ErrTorrentAddFailed)
+	assert.Contains(t, err.Error(), "request 2 of 2")
+	require.NotNil(t, res, "responses of earlier requests are kept")
+	assert.Equal(t, int64(1), res.SuccessCount)
+}
+
+func TestClient_AddTorrentsFromMemory_RetryReplaysBody(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			// torrents/add is not idempotent, so only retried when the server refused it
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		require.NoError(t, r.ParseMultipartForm(1<<20))
+		file, _, err := r.FormFile("torrents")
+		require.NoError(t, err)
+		data, err := io.ReadAll(file)
+		require.NoError(t, err)
+		assert.Equal(t, strings.Repeat("x", 4096), string(data))
+
+		w.Header().Set("Content-Type", "text/plain")
+		_, _ = w.Write([]byte("Ok."))
+	}))
+	t.Cleanup(server.Close)
+
+	client := NewClient(Config{Host: server.URL, APIKey: "key"})
+
+	_, err := client.AddTorrentsFromMemory([][]byte{bytes.Repeat([]byte{'x'}, 4096)}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestClient_AddTorrentsFromMemory_MiddlewareSkipsSend(t *testing.T) {
+	client := NewClient(Config{Host: "http://localhost:1", APIKey: "key"}).Use(func(RequestHandler) RequestHandler {
+		return func(context.Context, *Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Content-Type": {"text/plain"}},
+				Body:       io.NopCloser(strings.NewReader("Ok.")),
+			}, nil
+		}
+	})
+
+	var opened atomic.Int32
+	file := uploadFile{size: 1, open: func() (io.ReadCloser, error) {
+		opened.Add(1)
+		return io.NopCloser(strings.NewReader("x")), nil
+	}}
+
+	_, err := client.uploadTorrentsCtx(context.Background(), []uploadFile{file}, nil, nil)
+	require.NoError(t, err)
+	assert.Zero(t, opened.Load(), "the body is not built unless the request is sent")
+}
@@ -0,0 +1,82 @@
+package qbittorrent
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newWebSeedTestServer(t *testing.T, version string, handler http.HandlerFunc) *httptest.Server {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v2/app/webapiVersion", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(version))
+	})
+	mux.HandleFunc("/api/v2/torrents/", handler)
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestClient_AddTorrentWebSeeds(t *testing.T) {
+	server := newWebSeedTestServer(t, "2.11.3", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/v2/torrents/addWebSeeds", r.URL.Path)
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "abc", r.FormValue("hash"))
+		assert.Equal(t, "https://a.example/|https://b.example/", r.FormValue("urls"))
+	})
+
+	client := NewClient(Config{Host: server.URL})
+
+	err := client.AddTorrentWebSeeds("abc", []string{"https://a.example/", "https://b.example/"})
+	assert.NoError(t, err)
+}
+
+func TestClient_WebSeeds_UnsupportedVersion(t *testing.T) {
+	server := newWebSeedTestServer(t, "2.11.2", func(_ http.ResponseWriter, _ *http.Request) {
+		t.Fatal("request should not be sent to an unsupported server")
+	})
+
+	client := NewClient(Config{Host: server.URL})
+
+	assert.ErrorIs(t, client.AddTorrentWebSeeds("abc", []string{"https://a.example/"}), ErrUnsupportedVersion)
+	assert.ErrorIs(t, client.EditTorrentWebSeed("abc", "https://a.example/", "https://b.example/"), ErrUnsupportedVersion)
+	assert.ErrorIs(t, client.RemoveTorrentWebSeeds("abc", []string{"https://a.example/"}), ErrUnsupportedVersion)
+}
+
+func TestClient_EditTorrentWebSeed_StatusCodes(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		want   error
+	}{
+		{name: "ok", status: http.StatusOK, want: nil},
+		{name: "invalid url", status: http.StatusBadRequest, want: ErrInvalidURL},
+		{name: "torrent not found", status: http.StatusNotFound, want: ErrTorrentNotFound},
+		{name: "webseed not found", status: http.StatusConflict, want: ErrWebSeedNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newWebSeedTestServer(t, "2.11.3", func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, "/api/v2/torrents/editWebSeed", r.URL.Path)
+				w.WriteHeader(tt.status)
+			})
+
+			client := NewClient(Config{Host: server.URL})
+
+			err := client.EditTorrentWebSeed("abc", "https://a.example/", "https://b.example/")
+			if tt.want == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, tt.want)
+		})
+	}
+}